@@ -0,0 +1,269 @@
+package gogeek
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned when a request is rejected because the client's circuit breaker is open
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// CircuitState is the state of a CircuitBreaker
+type CircuitState int
+
+const (
+	// CircuitClosed lets every request through while counting failures
+	CircuitClosed CircuitState = iota
+	// CircuitOpen rejects every request with ErrCircuitOpen
+	CircuitOpen
+	// CircuitHalfOpen lets a limited number of probe requests through to test whether BGG has recovered
+	CircuitHalfOpen
+)
+
+// String returns the name of the state
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// CircuitBreakerPolicy controls when a CircuitBreaker trips and how it recovers.
+//
+// Transport errors and 5xx responses count as failures. Other responses, including
+// rate limited (429) and queued (202) responses, count as successes.
+type CircuitBreakerPolicy struct {
+	// FailureRatio is the fraction of failed requests within Window that trips the breaker (e.g., 0.5)
+	// A value of 0 uses the default of 0.5. The breaker never trips without at least one failure.
+	FailureRatio float64
+	// MinRequests is the number of requests that must be made within Window before the breaker can trip
+	// A value of 0 uses the default of 10
+	MinRequests int
+	// Window is how long failures are counted for before the counts are reset
+	// A value of 0 keeps counting until the breaker trips
+	Window time.Duration
+	// OpenTimeout is how long the breaker stays open before letting probe requests through
+	// A value of 0 uses the default of 30 seconds
+	OpenTimeout time.Duration
+	// HalfOpenProbes is the number of probe requests allowed while half-open, all of which
+	// must succeed to close the breaker. A value below 1 is treated as 1.
+	HalfOpenProbes int
+}
+
+// DefaultCircuitBreakerPolicy returns a policy that trips when at least half of 10 or more
+// requests within a minute fail, and sends a single probe request after 30 seconds
+func DefaultCircuitBreakerPolicy() CircuitBreakerPolicy {
+	return CircuitBreakerPolicy{
+		FailureRatio:   0.5,
+		MinRequests:    10,
+		Window:         time.Minute,
+		OpenTimeout:    30 * time.Second,
+		HalfOpenProbes: 1,
+	}
+}
+
+// CircuitBreaker stops requests being sent to BGG during a sustained outage, so callers fail
+// fast with ErrCircuitOpen instead of waiting through every retry.
+//
+// A single CircuitBreaker can be shared by several clients. It is safe for concurrent use.
+type CircuitBreaker struct {
+	mu          sync.Mutex
+	policy      CircuitBreakerPolicy
+	state       CircuitState
+	windowStart time.Time
+	requests    int
+	failures    int
+	openedAt    time.Time
+	probes      int
+	successes   int
+	generation  uint64
+	now         func() time.Time
+}
+
+// admission identifies a request let through by allow. Its generation is the breaker's
+// state generation at the time, so done can ignore requests admitted before the breaker
+// last changed state.
+type admission struct {
+	generation uint64
+}
+
+// outcome is the result of a request sent through a CircuitBreaker
+type outcome int
+
+const (
+	outcomeSuccess outcome = iota
+	outcomeFailure
+	// outcomeAbandoned is a request cancelled by its caller, which says nothing about BGG's health
+	outcomeAbandoned
+)
+
+// NewCircuitBreaker creates a closed CircuitBreaker with the given policy
+//
+// Example:
+//
+//	breaker := gogeek.NewCircuitBreaker(gogeek.DefaultCircuitBreakerPolicy())
+//	client := gogeek.NewClient(gogeek.WithCircuitBreaker(breaker))
+func NewCircuitBreaker(policy CircuitBreakerPolicy) *CircuitBreaker {
+	defaults := DefaultCircuitBreakerPolicy()
+	if policy.FailureRatio <= 0 {
+		policy.FailureRatio = defaults.FailureRatio
+	}
+	if policy.MinRequests <= 0 {
+		policy.MinRequests = defaults.MinRequests
+	}
+	if policy.OpenTimeout <= 0 {
+		policy.OpenTimeout = defaults.OpenTimeout
+	}
+	if policy.HalfOpenProbes < 1 {
+		policy.HalfOpenProbes = 1
+	}
+
+	return &CircuitBreaker{policy: policy, now: time.Now, windowStart: time.Now()}
+}
+
+// State returns the current state of the breaker, for use in health checks
+func (b *CircuitBreaker) State() CircuitState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.advance(b.now())
+	return b.state
+}
+
+// allow reports whether a request may be sent, returning ErrCircuitOpen if not.
+// Every successful call must be followed by a call to done with the returned admission.
+func (b *CircuitBreaker) allow() (admission, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.advance(b.now())
+
+	switch b.state {
+	case CircuitOpen:
+		return admission{}, ErrCircuitOpen
+	case CircuitHalfOpen:
+		if b.probes+b.successes >= b.policy.HalfOpenProbes {
+			return admission{}, ErrCircuitOpen
+		}
+		b.probes++
+	}
+
+	return admission{generation: b.generation}, nil
+}
+
+// done records the outcome of a request let through by allow. Outcomes of requests
+// admitted before the breaker last changed state are ignored, so a slow request sent
+// while closed can't count as a half-open probe.
+func (b *CircuitBreaker) done(a admission, result outcome) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	b.advance(now)
+
+	if a.generation != b.generation {
+		return
+	}
+
+	switch b.state {
+	case CircuitClosed:
+		if result == outcomeAbandoned {
+			return
+		}
+		b.requests++
+		if result == outcomeFailure {
+			b.failures++
+		}
+		if b.failures > 0 && b.requests >= b.policy.MinRequests && float64(b.failures) >= b.policy.FailureRatio*float64(b.requests) {
+			b.open(now)
+		}
+	case CircuitHalfOpen:
+		b.probes--
+		switch result {
+		case outcomeFailure:
+			b.open(now)
+		case outcomeSuccess:
+			b.successes++
+			if b.successes >= b.policy.HalfOpenProbes {
+				b.close(now)
+			}
+		}
+	}
+}
+
+// advance moves the breaker to half-open once its open timeout has passed, and starts
+// a new counting window once the current one has ended. It must be called with b.mu held.
+func (b *CircuitBreaker) advance(now time.Time) {
+	switch b.state {
+	case CircuitOpen:
+		if now.Sub(b.openedAt) >= b.policy.OpenTimeout {
+			b.state = CircuitHalfOpen
+			b.generation++
+			b.probes, b.successes = 0, 0
+		}
+	case CircuitClosed:
+		if b.policy.Window > 0 && now.Sub(b.windowStart) >= b.policy.Window {
+			b.windowStart = now
+			b.requests, b.failures = 0, 0
+		}
+	}
+}
+
+func (b *CircuitBreaker) open(now time.Time) {
+	b.state = CircuitOpen
+	b.generation++
+	b.openedAt = now
+}
+
+func (b *CircuitBreaker) close(now time.Time) {
+	b.state = CircuitClosed
+	b.generation++
+	b.windowStart = now
+	b.requests, b.failures = 0, 0
+}
+
+// middleware sends each request through the breaker, rejecting it with ErrCircuitOpen while the breaker is open
+func (b *CircuitBreaker) middleware(next Doer) Doer {
+	return DoerFunc(func(req *http.Request) (*http.Response, error) {
+		a, err := b.allow()
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := next.Do(req)
+
+		switch {
+		case err != nil && req.Context().Err() != nil:
+			b.done(a, outcomeAbandoned)
+		case err != nil || resp.StatusCode >= http.StatusInternalServerError:
+			b.done(a, outcomeFailure)
+		default:
+			b.done(a, outcomeSuccess)
+		}
+
+		return resp, err
+	})
+}
+
+// CircuitBreaker returns the client's circuit breaker, or nil if it doesn't have one
+func (c *Client) CircuitBreaker() *CircuitBreaker {
+	return c.breaker
+}
+
+// WithCircuitBreaker configures the client to send every request through breaker.
+// While the breaker is open, calls fail immediately with an error wrapping ErrCircuitOpen
+// instead of being sent and retried. Passing the same breaker to several clients makes
+// them trip together.
+func WithCircuitBreaker(breaker *CircuitBreaker) ClientOption {
+	return func(c *Client) {
+		c.breaker = breaker
+	}
+}
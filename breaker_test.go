@@ -0,0 +1,162 @@
+package gogeek
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// newTestBreaker returns a breaker whose clock is controlled by the returned function
+func newTestBreaker(policy CircuitBreakerPolicy) (*CircuitBreaker, func(time.Duration)) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	breaker := NewCircuitBreaker(policy)
+	breaker.now = func() time.Time { return now }
+	breaker.windowStart = now
+
+	return breaker, func(d time.Duration) { now = now.Add(d) }
+}
+
+// admit lets a request through breaker, failing the test if it is rejected
+func admit(t *testing.T, breaker *CircuitBreaker) admission {
+	t.Helper()
+	a, err := breaker.allow()
+	require.NoError(t, err, "Request should be allowed")
+	return a
+}
+
+// statusDoer returns a Doer responding with the given status, or failing if status is 0
+func statusDoer(status *int) Doer {
+	return DoerFunc(func(req *http.Request) (*http.Response, error) {
+		if *status == 0 {
+			return nil, errors.New("connection refused")
+		}
+		return &http.Response{StatusCode: *status, Body: http.NoBody}, nil
+	})
+}
+
+func TestCircuitBreaker_Trips(t *testing.T) {
+	breaker, _ := newTestBreaker(CircuitBreakerPolicy{FailureRatio: 0.5, MinRequests: 4, OpenTimeout: time.Minute})
+	status := http.StatusOK
+	doer := breaker.middleware(statusDoer(&status))
+	req, _ := http.NewRequest("GET", "https://example.com/xmlapi2/thing?id=1", nil)
+
+	for _, s := range []int{http.StatusOK, http.StatusServiceUnavailable, http.StatusTooManyRequests} {
+		status = s
+		_, err := doer.Do(req)
+		require.NoError(t, err, "Requests should be sent while the breaker is closed")
+	}
+	require.Equal(t, CircuitClosed, breaker.State(), "Breaker should stay closed below MinRequests")
+
+	status = 0
+	_, err := doer.Do(req)
+	require.EqualError(t, err, "connection refused", "Transport errors should be returned")
+	require.Equal(t, CircuitOpen, breaker.State(), "Breaker should trip at the failure ratio")
+
+	status = http.StatusOK
+	_, err = doer.Do(req)
+	require.ErrorIs(t, err, ErrCircuitOpen, "Requests should be rejected while the breaker is open")
+}
+
+func TestCircuitBreaker_WindowResetsCounts(t *testing.T) {
+	breaker, advance := newTestBreaker(CircuitBreakerPolicy{FailureRatio: 0.5, MinRequests: 2, Window: time.Minute, OpenTimeout: time.Hour})
+
+	breaker.done(admit(t, breaker), outcomeFailure)
+	advance(time.Minute)
+
+	breaker.done(admit(t, breaker), outcomeSuccess)
+	breaker.done(admit(t, breaker), outcomeFailure)
+	require.Equal(t, CircuitOpen, breaker.State(), "Failures in the current window should trip the breaker")
+
+	breaker, advance = newTestBreaker(CircuitBreakerPolicy{FailureRatio: 0.5, MinRequests: 2, Window: time.Minute, OpenTimeout: time.Hour})
+	breaker.done(admit(t, breaker), outcomeFailure)
+	advance(time.Minute)
+	breaker.done(admit(t, breaker), outcomeSuccess)
+	require.Equal(t, CircuitClosed, breaker.State(), "Failures from an earlier window should not count")
+}
+
+func TestCircuitBreaker_HalfOpen(t *testing.T) {
+	breaker, advance := newTestBreaker(CircuitBreakerPolicy{FailureRatio: 1, MinRequests: 1, OpenTimeout: time.Minute, HalfOpenProbes: 2})
+
+	breaker.done(admit(t, breaker), outcomeFailure)
+	require.Equal(t, CircuitOpen, breaker.State(), "Breaker should trip")
+
+	advance(time.Minute)
+	require.Equal(t, CircuitHalfOpen, breaker.State(), "Breaker should half-open after the open timeout")
+
+	first := admit(t, breaker)
+	second := admit(t, breaker)
+	_, err := breaker.allow()
+	require.ErrorIs(t, err, ErrCircuitOpen, "Requests beyond the probes should be rejected")
+
+	breaker.done(first, outcomeSuccess)
+	breaker.done(second, outcomeFailure)
+	require.Equal(t, CircuitOpen, breaker.State(), "A failed probe should reopen the breaker")
+
+	advance(time.Minute)
+	breaker.done(admit(t, breaker), outcomeAbandoned)
+	require.Equal(t, CircuitHalfOpen, breaker.State(), "Abandoned probes should not close the breaker")
+
+	for i := 0; i < 2; i++ {
+		breaker.done(admit(t, breaker), outcomeSuccess)
+	}
+	require.Equal(t, CircuitClosed, breaker.State(), "Successful probes should close the breaker")
+}
+
+func TestCircuitBreaker_ZeroPolicy(t *testing.T) {
+	breaker, _ := newTestBreaker(CircuitBreakerPolicy{})
+	defaults := DefaultCircuitBreakerPolicy()
+	defaults.Window = 0
+	require.Equal(t, defaults, breaker.policy, "Zero fields should use the defaults")
+
+	breaker, _ = newTestBreaker(CircuitBreakerPolicy{MinRequests: 3, OpenTimeout: time.Minute})
+	for i := 0; i < 3; i++ {
+		breaker.done(admit(t, breaker), outcomeSuccess)
+	}
+	require.Equal(t, CircuitClosed, breaker.State(), "Successful requests should never trip the breaker")
+}
+
+func TestCircuitBreaker_StaleAdmissionIgnored(t *testing.T) {
+	breaker, advance := newTestBreaker(CircuitBreakerPolicy{FailureRatio: 1, MinRequests: 1, OpenTimeout: time.Minute})
+
+	slow := admit(t, breaker)
+	breaker.done(admit(t, breaker), outcomeFailure)
+	advance(time.Minute)
+	require.Equal(t, CircuitHalfOpen, breaker.State())
+
+	breaker.done(slow, outcomeSuccess)
+	require.Equal(t, CircuitHalfOpen, breaker.State(), "A request sent while closed should not count as a probe")
+	require.Equal(t, 0, breaker.probes, "A request sent while closed should not release a probe")
+
+	probe := admit(t, breaker)
+	_, err := breaker.allow()
+	require.ErrorIs(t, err, ErrCircuitOpen, "Only the configured probes should be let through")
+	breaker.done(probe, outcomeSuccess)
+	require.Equal(t, CircuitClosed, breaker.State(), "A successful probe should close the breaker")
+}
+
+func TestCircuitBreaker_CancelledRequestsNotCounted(t *testing.T) {
+	breaker, _ := newTestBreaker(CircuitBreakerPolicy{FailureRatio: 0.5, MinRequests: 1})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	req, _ := http.NewRequestWithContext(ctx, "GET", "https://example.com/xmlapi2/thing?id=1", nil)
+	status := 0
+	_, err := breaker.middleware(statusDoer(&status)).Do(req)
+
+	require.Error(t, err, "Transport error should be returned")
+	require.Equal(t, CircuitClosed, breaker.State(), "Cancelled requests should not trip the breaker")
+}
+
+func TestNewClient_WithCircuitBreaker(t *testing.T) {
+	require.Nil(t, NewClient().CircuitBreaker(), "Clients should have no circuit breaker by default")
+
+	breaker := NewCircuitBreaker(DefaultCircuitBreakerPolicy())
+	client := NewClient(WithCircuitBreaker(breaker))
+
+	require.Same(t, breaker, client.CircuitBreaker(), "Circuit breaker should be the one provided")
+	require.Equal(t, "closed", breaker.State().String(), "New breakers should be closed")
+}
@@ -0,0 +1,81 @@
+package gogeek
+
+import (
+	"context"
+	"time"
+)
+
+// Cache stores raw BGG API responses so repeated requests don't count against the rate limit.
+//
+// Keys are derived from the canonical request URL and the client's authentication identity.
+// Implementations must be safe for concurrent use. The cache package provides in-memory
+// and filesystem implementations.
+type Cache interface {
+	// Get returns the cached value for key and whether it was found and has not expired
+	Get(key string) ([]byte, bool)
+	// Set stores value for key, expiring after ttl
+	Set(key string, value []byte, ttl time.Duration)
+}
+
+// DefaultCacheTTLs returns how long responses from each endpoint are cached by default
+// Endpoints are identified by name (e.g., "thing", "hot"); endpoints without a TTL are not cached
+func DefaultCacheTTLs() map[string]time.Duration {
+	return map[string]time.Duration{
+		"collection": 2 * time.Minute,
+		"family":     6 * time.Hour,
+		"forum":      5 * time.Minute,
+		"forumlist":  time.Hour,
+		"guild":      6 * time.Hour,
+		"hot":        15 * time.Minute,
+		"plays":      5 * time.Minute,
+		"search":     time.Hour,
+		"thing":      6 * time.Hour,
+		"thread":     5 * time.Minute,
+		"user":       time.Hour,
+	}
+}
+
+// Cache returns the response cache for this client, or nil if caching is disabled
+func (c *Client) Cache() Cache {
+	return c.cache
+}
+
+// CacheTTL returns how long responses from the named endpoint (e.g., "thing") are cached
+// A zero duration means responses from the endpoint are not cached
+func (c *Client) CacheTTL(endpoint string) time.Duration {
+	if ttl, ok := c.cacheTTLs[endpoint]; ok {
+		return ttl
+	}
+	return DefaultCacheTTLs()[endpoint]
+}
+
+// WithCache configures the client to cache responses in the given Cache
+// Caching is disabled by default
+func WithCache(cache Cache) ClientOption {
+	return func(c *Client) {
+		c.cache = cache
+	}
+}
+
+// WithCacheTTL overrides how long responses from the named endpoint (e.g., "hot") are cached
+// A zero duration disables caching for the endpoint
+func WithCacheTTL(endpoint string, ttl time.Duration) ClientOption {
+	return func(c *Client) {
+		if c.cacheTTLs == nil {
+			c.cacheTTLs = make(map[string]time.Duration)
+		}
+		c.cacheTTLs[endpoint] = ttl
+	}
+}
+
+// ContextWithoutCache returns a context that bypasses the cache for a single call
+// The response is always fetched from BGG, and the fresh response replaces any cached copy
+func ContextWithoutCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, bypassCacheKey, true)
+}
+
+// CacheBypassed reports whether ContextWithoutCache was used to create ctx
+func CacheBypassed(ctx context.Context) bool {
+	bypass, _ := ctx.Value(bypassCacheKey).(bool)
+	return bypass
+}
@@ -0,0 +1,88 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// expiryHeaderLength is the size of the expiry timestamp stored at the start of each cache file
+const expiryHeaderLength = 8
+
+// File is a filesystem cache that stores each entry as a file in a directory.
+// It is safe for concurrent use, including by multiple processes sharing the directory.
+type File struct {
+	dir string
+	now func() time.Time
+}
+
+// NewFile creates a filesystem cache in dir, creating the directory if needed.
+//
+// Example:
+//
+//	fileCache, err := cache.NewFile(filepath.Join(os.TempDir(), "gogeek"))
+//	if err != nil {
+//	    log.Fatalf("Failed to create cache: %v", err)
+//	}
+//	client := gogeek.NewClient(gogeek.WithCache(fileCache))
+func NewFile(dir string) (*File, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	return &File{dir: dir, now: time.Now}, nil
+}
+
+// Get returns the cached value for key if it is present and has not expired
+func (f *File) Get(key string) ([]byte, bool) {
+	data, err := os.ReadFile(f.path(key))
+	if err != nil || len(data) < expiryHeaderLength {
+		return nil, false
+	}
+
+	expires := time.Unix(0, int64(binary.BigEndian.Uint64(data[:expiryHeaderLength])))
+	if !f.now().Before(expires) {
+		os.Remove(f.path(key))
+		return nil, false
+	}
+
+	return data[expiryHeaderLength:], true
+}
+
+// Set stores value for key, expiring after ttl. Non-positive TTLs are ignored.
+// Write errors are ignored, as a failed cache write only costs a future request.
+func (f *File) Set(key string, value []byte, ttl time.Duration) {
+	if ttl <= 0 {
+		return
+	}
+
+	data := make([]byte, expiryHeaderLength+len(value))
+	binary.BigEndian.PutUint64(data[:expiryHeaderLength], uint64(f.now().Add(ttl).UnixNano()))
+	copy(data[expiryHeaderLength:], value)
+
+	tmp, err := os.CreateTemp(f.dir, "tmp-*")
+	if err != nil {
+		return
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return
+	}
+	if err := tmp.Close(); err != nil {
+		return
+	}
+
+	os.Rename(tmp.Name(), f.path(key))
+}
+
+// path returns the file used to store key, named after a hash of the key
+func (f *File) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(f.dir, hex.EncodeToString(sum[:])+".cache")
+}
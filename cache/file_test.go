@@ -0,0 +1,61 @@
+package cache
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/kkjdaniel/gogeek/v2"
+	"github.com/stretchr/testify/require"
+)
+
+var _ gogeek.Cache = (*File)(nil)
+
+func TestFile_GetSet(t *testing.T) {
+	cache, err := NewFile(t.TempDir())
+	require.NoError(t, err, "NewFile should create the cache")
+
+	_, ok := cache.Get("missing")
+	require.False(t, ok, "Missing keys should not be found")
+
+	cache.Set("key", []byte("<items></items>"), time.Minute)
+	value, ok := cache.Get("key")
+	require.True(t, ok, "Stored keys should be found")
+	require.Equal(t, []byte("<items></items>"), value, "Stored value should be returned")
+}
+
+func TestFile_Expiry(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	cache, err := NewFile(dir)
+	require.NoError(t, err, "NewFile should create the cache")
+	cache.now = func() time.Time { return now }
+
+	cache.Set("key", []byte("value"), time.Minute)
+	cache.Set("ignored", []byte("value"), 0)
+
+	_, ok := cache.Get("ignored")
+	require.False(t, ok, "Entries with a zero TTL should not be stored")
+
+	now = now.Add(time.Minute)
+	_, ok = cache.Get("key")
+	require.False(t, ok, "Entry should not be found once it expires")
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Empty(t, entries, "Expired entries should be removed from disk")
+}
+
+func TestFile_SharedDirectory(t *testing.T) {
+	dir := t.TempDir()
+
+	first, err := NewFile(dir)
+	require.NoError(t, err)
+	first.Set("key", []byte("value"), time.Minute)
+
+	second, err := NewFile(dir)
+	require.NoError(t, err)
+	value, ok := second.Get("key")
+	require.True(t, ok, "Entries should be visible to other caches using the same directory")
+	require.Equal(t, []byte("value"), value)
+}
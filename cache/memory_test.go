@@ -0,0 +1,67 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kkjdaniel/gogeek/v2"
+	"github.com/stretchr/testify/require"
+)
+
+var _ gogeek.Cache = (*Memory)(nil)
+
+func TestMemory_GetSet(t *testing.T) {
+	cache := NewMemory(10)
+
+	_, ok := cache.Get("missing")
+	require.False(t, ok, "Missing keys should not be found")
+
+	cache.Set("key", []byte("value"), time.Minute)
+	value, ok := cache.Get("key")
+	require.True(t, ok, "Stored keys should be found")
+	require.Equal(t, []byte("value"), value, "Stored value should be returned")
+
+	cache.Set("key", []byte("updated"), time.Minute)
+	value, _ = cache.Get("key")
+	require.Equal(t, []byte("updated"), value, "Setting an existing key should replace its value")
+	require.Equal(t, 1, cache.Len(), "Replacing a value should not add an entry")
+}
+
+func TestMemory_Expiry(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	cache := NewMemory(10)
+	cache.now = func() time.Time { return now }
+
+	cache.Set("key", []byte("value"), time.Minute)
+	cache.Set("ignored", []byte("value"), 0)
+
+	_, ok := cache.Get("ignored")
+	require.False(t, ok, "Entries with a zero TTL should not be stored")
+
+	now = now.Add(59 * time.Second)
+	_, ok = cache.Get("key")
+	require.True(t, ok, "Entry should be found before it expires")
+
+	now = now.Add(time.Second)
+	_, ok = cache.Get("key")
+	require.False(t, ok, "Entry should not be found once it expires")
+	require.Equal(t, 0, cache.Len(), "Expired entries should be evicted on access")
+}
+
+func TestMemory_EvictsLeastRecentlyUsed(t *testing.T) {
+	cache := NewMemory(2)
+
+	cache.Set("a", []byte("1"), time.Minute)
+	cache.Set("b", []byte("2"), time.Minute)
+	cache.Get("a")
+	cache.Set("c", []byte("3"), time.Minute)
+
+	_, ok := cache.Get("b")
+	require.False(t, ok, "Least recently used entry should be evicted")
+
+	_, ok = cache.Get("a")
+	require.True(t, ok, "Recently used entry should be kept")
+
+	_, ok = cache.Get("c")
+	require.True(t, ok, "Newest entry should be kept")
+}
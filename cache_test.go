@@ -0,0 +1,28 @@
+package gogeek
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCacheTTL(t *testing.T) {
+	client := NewClient()
+
+	require.Nil(t, client.Cache(), "Caching should be disabled by default")
+	require.Equal(t, 15*time.Minute, client.CacheTTL("hot"), "Hot list should use its default TTL")
+	require.Equal(t, 6*time.Hour, client.CacheTTL("thing"), "Thing should use its default TTL")
+	require.Equal(t, time.Duration(0), client.CacheTTL("unknown"), "Unknown endpoints should not be cached")
+
+	client = NewClient(WithCacheTTL("hot", time.Minute), WithCacheTTL("collection", 0))
+	require.Equal(t, time.Minute, client.CacheTTL("hot"), "TTL should be overridable")
+	require.Equal(t, time.Duration(0), client.CacheTTL("collection"), "TTL should be disableable")
+	require.Equal(t, 6*time.Hour, client.CacheTTL("thing"), "Other endpoints should keep their defaults")
+}
+
+func TestContextWithoutCache(t *testing.T) {
+	require.False(t, CacheBypassed(context.Background()), "Cache should be used by default")
+	require.True(t, CacheBypassed(ContextWithoutCache(context.Background())), "Cache should be bypassed")
+}
@@ -0,0 +1,294 @@
+package cassette
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/kkjdaniel/gogeek/v2"
+)
+
+// RecordEnv is the environment variable that switches NewForTest into record mode when set to "1"
+const RecordEnv = "GOGEEK_RECORD"
+
+// ErrUnmatchedRequest is returned in replay mode when no recorded interaction matches a request
+var ErrUnmatchedRequest = errors.New("no recorded interaction matches request")
+
+// Mode controls whether a Recorder captures real responses or replays recorded ones
+type Mode int
+
+const (
+	// ModeReplay serves responses from the cassette and fails any request it doesn't contain
+	ModeReplay Mode = iota
+	// ModeRecord sends requests to the real transport and captures the responses
+	ModeRecord
+)
+
+// scrubbedRequestHeaders are never written to a cassette
+var scrubbedRequestHeaders = []string{"Authorization", "Cookie"}
+
+// scrubbedResponseHeaders are never written to a cassette
+var scrubbedResponseHeaders = []string{"Set-Cookie"}
+
+// Cassette is the set of HTTP interactions stored in a cassette file
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is a single recorded request and its response
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request is a recorded request. Credentials are removed from the URL and headers.
+type Request struct {
+	Method  string      `json:"method"`
+	URL     string      `json:"url"`
+	Headers http.Header `json:"headers,omitempty"`
+}
+
+// Response is a recorded response. Bodies that aren't valid UTF-8, such as compressed
+// bodies, are stored base64 encoded in BodyBase64 instead of Body.
+type Response struct {
+	StatusCode int         `json:"status_code"`
+	Headers    http.Header `json:"headers,omitempty"`
+	Body       string      `json:"body,omitempty"`
+	BodyBase64 string      `json:"body_base64,omitempty"`
+}
+
+// Option configures a Recorder
+type Option func(*Recorder)
+
+// WithTransport sets the transport used to send requests in record mode, which defaults to http.DefaultTransport
+func WithTransport(transport http.RoundTripper) Option {
+	return func(r *Recorder) {
+		if transport != nil {
+			r.transport = transport
+		}
+	}
+}
+
+// Recorder is an http.RoundTripper that records real BGG responses to a cassette
+// file or replays them, so tests can run deterministically and offline.
+//
+// In replay mode requests are matched on method, path and canonical query (with
+// parameters sorted), ignoring the host, so a cassette can be replayed against any
+// base URL. Each recorded interaction is served once, in the order it was recorded,
+// which lets a cassette replay queued (202) polls and retries faithfully.
+// It is safe for concurrent use.
+type Recorder struct {
+	mu        sync.Mutex
+	path      string
+	mode      Mode
+	transport http.RoundTripper
+	cassette  Cassette
+	used      []bool
+}
+
+// New creates a Recorder for the cassette file at path. In replay mode the file is
+// loaded immediately; in record mode requests are sent to the real transport and
+// the cassette is written when Save is called.
+//
+// Example:
+//
+//	recorder, err := cassette.New("testdata/hot.json", cassette.ModeReplay)
+//	if err != nil {
+//	    return err
+//	}
+//	client := gogeek.NewClient(gogeek.WithTransport(recorder))
+func New(path string, mode Mode, opts ...Option) (*Recorder, error) {
+	r := &Recorder{path: path, mode: mode, transport: http.DefaultTransport}
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	if mode == ModeReplay {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read cassette: %w", err)
+		}
+		if err := json.Unmarshal(data, &r.cassette); err != nil {
+			return nil, fmt.Errorf("failed to parse cassette %s: %w", path, err)
+		}
+		r.used = make([]bool, len(r.cassette.Interactions))
+	}
+
+	return r, nil
+}
+
+// NewForTest creates a Recorder for the cassette file at path that replays it, or
+// records a new one if the GOGEEK_RECORD environment variable is "1". Recorded
+// cassettes are saved when the test finishes.
+//
+// Example:
+//
+//	func TestPlays(t *testing.T) {
+//	    client := gogeek.NewClient(gogeek.WithTransport(cassette.NewForTest(t, "testdata/plays.json")))
+//	    ...
+//	}
+func NewForTest(t testing.TB, path string, opts ...Option) *Recorder {
+	t.Helper()
+
+	mode := ModeReplay
+	if os.Getenv(RecordEnv) == "1" {
+		mode = ModeRecord
+	}
+
+	r, err := New(path, mode, opts...)
+	if err != nil {
+		t.Fatalf("Failed to load cassette: %v", err)
+	}
+
+	if mode == ModeRecord {
+		t.Cleanup(func() {
+			if err := r.Save(); err != nil {
+				t.Errorf("Failed to save cassette: %v", err)
+			}
+		})
+	}
+
+	return r
+}
+
+// Mode returns whether the recorder is recording or replaying
+func (r *Recorder) Mode() Mode {
+	return r.mode
+}
+
+// RoundTrip records or replays a single HTTP request
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	if r.mode == ModeRecord {
+		return r.record(req)
+	}
+	return r.replay(req)
+}
+
+// Save writes the recorded interactions to the cassette file, creating its directory if needed
+func (r *Recorder) Save() error {
+	r.mu.Lock()
+	data, err := json.MarshalIndent(r.cassette, "", "  ")
+	r.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(r.path, append(data, '\n'), 0o644)
+}
+
+func (r *Recorder) record(req *http.Request) (*http.Response, error) {
+	resp, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	recorded := Response{
+		StatusCode: resp.StatusCode,
+		Headers:    scrub(resp.Header, scrubbedResponseHeaders),
+	}
+	if utf8.Valid(body) {
+		recorded.Body = string(body)
+	} else {
+		recorded.BodyBase64 = base64.StdEncoding.EncodeToString(body)
+	}
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{
+		Request: Request{
+			Method:  req.Method,
+			URL:     gogeek.RedactURL(req.URL.String()),
+			Headers: scrub(req.Header, scrubbedRequestHeaders),
+		},
+		Response: recorded,
+	})
+	r.mu.Unlock()
+
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return resp, nil
+}
+
+func (r *Recorder) replay(req *http.Request) (*http.Response, error) {
+	key := matchKey(req.Method, gogeek.RedactURL(req.URL.String()))
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, interaction := range r.cassette.Interactions {
+		if r.used[i] || matchKey(interaction.Request.Method, interaction.Request.URL) != key {
+			continue
+		}
+		r.used[i] = true
+		return interaction.Response.httpResponse(req)
+	}
+
+	return nil, fmt.Errorf("%w: %s %s", ErrUnmatchedRequest, req.Method, gogeek.RedactURL(req.URL.String()))
+}
+
+func (resp Response) httpResponse(req *http.Request) (*http.Response, error) {
+	body := []byte(resp.Body)
+	if resp.BodyBase64 != "" {
+		decoded, err := base64.StdEncoding.DecodeString(resp.BodyBase64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode recorded body: %w", err)
+		}
+		body = decoded
+	}
+
+	header := resp.Headers.Clone()
+	if header == nil {
+		header = make(http.Header)
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", resp.StatusCode, http.StatusText(resp.StatusCode)),
+		StatusCode:    resp.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+// matchKey identifies a request by method, path and canonical query, ignoring the host
+func matchKey(method, rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return method + " " + rawURL
+	}
+	return method + " " + u.Path + "?" + u.Query().Encode()
+}
+
+// scrub returns a copy of header without the named headers
+func scrub(header http.Header, names []string) http.Header {
+	scrubbed := header.Clone()
+	for _, name := range names {
+		scrubbed.Del(name)
+	}
+	if len(scrubbed) == 0 {
+		return nil
+	}
+	return scrubbed
+}
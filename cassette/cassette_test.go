@@ -0,0 +1,108 @@
+package cassette
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kkjdaniel/gogeek/v2"
+	"github.com/kkjdaniel/gogeek/v2/hot"
+	"github.com/kkjdaniel/gogeek/v2/request"
+	"github.com/stretchr/testify/require"
+)
+
+const hotXML = `<items termsofuse="https://boardgamegeek.com/xmlapi/termsofuse"><item id="1" rank="1"><name value="Game &amp; Co"/></item></items>`
+
+func TestRecorder_RecordAndReplay(t *testing.T) {
+	var calls int
+	var sawAuth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		sawAuth = r.Header.Get("Authorization")
+		w.Header().Set("Set-Cookie", "session=secret-session")
+		if calls == 1 {
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+		w.Write([]byte(hotXML))
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "cassettes", "hot.json")
+	queuePolicy := gogeek.WithQueuePolicy(gogeek.QueuePolicy{MaxPolls: 1, InitialDelay: time.Millisecond})
+
+	recorder, err := New(path, ModeRecord)
+	require.NoError(t, err, "Recorder should be created")
+
+	client := gogeek.NewClient(
+		gogeek.WithTransport(recorder),
+		gogeek.WithBaseURL(server.URL+"/xmlapi2"),
+		gogeek.WithAPIKey("secret-key"),
+		queuePolicy,
+	)
+	recorded, err := hot.Query(client, "boardgame")
+	require.NoError(t, err, "Recorded query should succeed")
+	require.Equal(t, "Bearer secret-key", sawAuth, "Real requests should be authenticated")
+	require.NoError(t, recorder.Save(), "Cassette should be saved")
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err, "Cassette file should exist")
+	require.NotContains(t, string(data), "secret", "Credentials should be scrubbed from the cassette")
+	require.Equal(t, 2, strings.Count(string(data), `"method": "GET"`), "Queued polls should be recorded")
+
+	replayer, err := New(path, ModeReplay)
+	require.NoError(t, err, "Cassette should be loaded")
+
+	client = gogeek.NewClient(gogeek.WithTransport(replayer), queuePolicy)
+	replayed, err := hot.Query(client, "boardgame")
+	require.NoError(t, err, "Replayed query should succeed against a different host")
+	require.Equal(t, recorded, replayed, "Replayed response should match the recording")
+	require.Equal(t, 2, calls, "Replay should not contact the server")
+}
+
+func TestRecorder_ReplayMatchesCanonicalQuery(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "thing.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{
+  "interactions": [
+    {
+      "request": {"method": "GET", "url": "https://boardgamegeek.com/xmlapi2/thing?id=1&stats=1"},
+      "response": {"status_code": 200, "body": "<items/>"}
+    }
+  ]
+}`), 0o644))
+
+	recorder, err := New(path, ModeReplay)
+	require.NoError(t, err, "Cassette should be loaded")
+
+	req, _ := http.NewRequest("GET", "http://localhost/xmlapi2/thing?stats=1&id=1", nil)
+	resp, err := recorder.RoundTrip(req)
+	require.NoError(t, err, "Reordered query parameters should match")
+	require.Equal(t, http.StatusOK, resp.StatusCode, "Recorded status should be replayed")
+
+	_, err = recorder.RoundTrip(req)
+	require.ErrorIs(t, err, ErrUnmatchedRequest, "Interactions should only be replayed once")
+
+	req, _ = http.NewRequest("GET", "http://localhost/xmlapi2/thing?id=2&stats=1", nil)
+	_, err = recorder.RoundTrip(req)
+	require.ErrorIs(t, err, ErrUnmatchedRequest, "Unmatched requests should fail")
+}
+
+func TestRecorder_UnmatchedRequestFailsQuery(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "empty.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"interactions": []}`), 0o644))
+
+	client := gogeek.NewClient(gogeek.WithTransport(NewForTest(t, path)))
+	_, err := hot.Query(client, "boardgame")
+
+	require.ErrorIs(t, err, request.ErrHTTPError, "Unmatched requests should fail the query")
+	require.ErrorIs(t, err, ErrUnmatchedRequest, "The cassette error should be available")
+}
+
+func TestNew_MissingCassette(t *testing.T) {
+	_, err := New(filepath.Join(t.TempDir(), "missing.json"), ModeReplay)
+	require.Error(t, err, "Replaying a missing cassette should fail")
+}
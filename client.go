@@ -1,7 +1,12 @@
 package gogeek
 
 import (
-	"go.uber.org/ratelimit"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/kkjdaniel/gogeek/v2/constants"
 )
 
 // AuthMode represents the authentication method for the client
@@ -18,15 +23,24 @@ const (
 
 // Client represents a GoGeek API client with configurable authentication
 type Client struct {
-	limiter      ratelimit.Limiter
-	authMode     AuthMode
-	apiKey       string
-	cookieString string
-}
-
-// Limiter returns the rate limiter for this client
-func (c *Client) Limiter() ratelimit.Limiter {
-	return c.limiter
+	limiter          Limiter
+	endpointLimiters map[string]Limiter
+	authMode         AuthMode
+	apiKey           string
+	cookieString     string
+	httpClient       *http.Client
+	baseURL          string
+	retryPolicy      RetryPolicy
+	queuePolicy      QueuePolicy
+	cache            Cache
+	cacheTTLs        map[string]time.Duration
+	middleware       []Middleware
+	logger           *slog.Logger
+	observer         Observer
+	breaker          *CircuitBreaker
+	userAgent        string
+	headers          http.Header
+	compression      bool
 }
 
 // AuthMode returns the authentication mode for this client
@@ -44,6 +58,21 @@ func (c *Client) CookieString() string {
 	return c.cookieString
 }
 
+// HTTPClient returns the HTTP client used to send requests to the BGG API
+func (c *Client) HTTPClient() *http.Client {
+	return c.httpClient
+}
+
+// BaseURL returns the base URL of the XML API2 that requests are sent to
+func (c *Client) BaseURL() string {
+	return c.baseURL
+}
+
+// Endpoint returns the full URL for an endpoint path (e.g. constants.ThingPath) relative to the client's base URL
+func (c *Client) Endpoint(path string) string {
+	return c.baseURL + path
+}
+
 // ClientOption is a functional option for configuring a Client
 type ClientOption func(*Client)
 
@@ -65,12 +94,50 @@ func WithCookie(cookie string) ClientOption {
 	}
 }
 
+// WithHTTPClient configures the client to send requests through the given http.Client
+// This can be used to set timeouts, proxies or custom TLS configuration
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(c *Client) {
+		if httpClient != nil {
+			c.httpClient = httpClient
+		}
+	}
+}
+
+// WithTransport configures the client to send requests through the given http.RoundTripper
+// A new http.Client owned by this client is created around the transport
+func WithTransport(transport http.RoundTripper) ClientOption {
+	return func(c *Client) {
+		if transport != nil {
+			c.httpClient = &http.Client{Transport: transport}
+		}
+	}
+}
+
+// WithBaseURL configures the client to send requests to a different XML API2 base URL
+// This can be used to target a caching mirror or a local stand-in server (e.g., "http://localhost:8080/xmlapi2")
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) {
+		if baseURL != "" {
+			c.baseURL = strings.TrimRight(baseURL, "/")
+		}
+	}
+}
+
 // NewClient creates a new GoGeek API client with optional configuration
-// By default, the client uses no authentication and has a rate limit of 2 requests per second
+// By default, the client uses no authentication, has a rate limit of 2 requests per second,
+// sends requests through http.DefaultClient to constants.BGGBaseURL identifying itself with
+// DefaultUserAgent, and asks for compressed responses
 func NewClient(opts ...ClientOption) *Client {
 	client := &Client{
-		limiter:  ratelimit.New(2, ratelimit.WithoutSlack),
-		authMode: AuthNone,
+		limiter:     NewRateLimiter(defaultRequestsPerSecond, 1),
+		authMode:    AuthNone,
+		httpClient:  http.DefaultClient,
+		baseURL:     constants.BGGBaseURL,
+		retryPolicy: DefaultRetryPolicy(),
+		queuePolicy: DefaultQueuePolicy(),
+		userAgent:   DefaultUserAgent,
+		compression: true,
 	}
 
 	for _, opt := range opts {
@@ -1,9 +1,14 @@
 package gogeek
 
 import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
 	"testing"
 	"time"
 
+	"github.com/kkjdaniel/gogeek/v2/constants"
 	"github.com/stretchr/testify/require"
 )
 
@@ -15,6 +20,9 @@ func TestNewClient_Default(t *testing.T) {
 	require.Equal(t, AuthNone, client.AuthMode(), "Default auth mode should be AuthNone")
 	require.Equal(t, "", client.APIKey(), "Default API key should be empty")
 	require.Equal(t, "", client.CookieString(), "Default cookie string should be empty")
+	require.Equal(t, http.DefaultClient, client.HTTPClient(), "Default HTTP client should be http.DefaultClient")
+	require.Equal(t, constants.BGGBaseURL, client.BaseURL(), "Default base URL should be the BGG XML API2")
+	require.Equal(t, constants.ThingEndpoint, client.Endpoint(constants.ThingPath), "Default endpoints should match the constants")
 }
 
 func TestNewClient_WithAPIKey(t *testing.T) {
@@ -37,12 +45,72 @@ func TestNewClient_WithCookie(t *testing.T) {
 	require.Equal(t, "", client.APIKey(), "API key should be empty")
 }
 
+func TestNewClient_WithHTTPClient(t *testing.T) {
+	httpClient := &http.Client{Timeout: 5 * time.Second}
+	client := NewClient(WithHTTPClient(httpClient))
+
+	require.Same(t, httpClient, client.HTTPClient(), "HTTP client should be the one provided")
+}
+
+func TestNewClient_WithTransport(t *testing.T) {
+	transport := &http.Transport{}
+	client := NewClient(WithTransport(transport))
+
+	require.NotSame(t, http.DefaultClient, client.HTTPClient(), "Client should own its HTTP client")
+	require.Same(t, transport, client.HTTPClient().Transport, "Transport should be the one provided")
+}
+
+func TestNewClient_WithNilHTTPClient(t *testing.T) {
+	client := NewClient(WithHTTPClient(nil), WithTransport(nil))
+
+	require.Equal(t, http.DefaultClient, client.HTTPClient(), "Nil options should keep the default HTTP client")
+}
+
+func TestNewClient_WithBaseURL(t *testing.T) {
+	client := NewClient(WithBaseURL("http://localhost:8080/xmlapi2/"))
+
+	require.Equal(t, "http://localhost:8080/xmlapi2", client.BaseURL(), "Trailing slash should be trimmed")
+	require.Equal(t, "http://localhost:8080/xmlapi2/thing", client.Endpoint(constants.ThingPath), "Endpoint should use the base URL")
+}
+
+func TestNewClient_WithLogger(t *testing.T) {
+	require.Nil(t, NewClient().Logger(), "Logging should be disabled by default")
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	client := NewClient(WithLogger(logger))
+
+	require.Equal(t, logger, client.Logger(), "Logger should be the one provided")
+}
+
+func TestNewClient_Headers(t *testing.T) {
+	client := NewClient()
+
+	require.Equal(t, DefaultUserAgent, client.UserAgent(), "Default User-Agent should identify gogeek")
+	require.Empty(t, client.Headers(), "Default client should have no extra headers")
+	require.True(t, client.Compression(), "Compression should be enabled by default")
+
+	client = NewClient(
+		WithUserAgent("my-app/1.0"),
+		WithUserAgent(""),
+		WithHeader("X-One", "1"),
+		WithHeader("x-one", "2"),
+		WithCompression(false),
+	)
+
+	require.Equal(t, "my-app/1.0", client.UserAgent(), "Empty User-Agent should be ignored")
+	require.Equal(t, http.Header{"X-One": {"2"}}, client.Headers(), "Later headers should replace earlier ones")
+	require.False(t, client.Compression(), "Compression should be disabled")
+
+	client.Headers().Set("X-Two", "2")
+	require.Empty(t, client.Headers().Get("X-Two"), "Headers should return a copy")
+}
+
 func TestNewClient_RateLimiter(t *testing.T) {
 	client := NewClient()
 
 	// Rate limiter should allow at least one call immediately
 	start := time.Now()
-	client.Limiter().Take()
+	client.Limiter().Wait(context.Background())
 	duration := time.Since(start)
 
 	// First call should be nearly instant
@@ -50,7 +118,7 @@ func TestNewClient_RateLimiter(t *testing.T) {
 
 	// Second call should be delayed by approximately 0.5 seconds (2 requests per second)
 	start = time.Now()
-	client.Limiter().Take()
+	client.Limiter().Wait(context.Background())
 	duration = time.Since(start)
 
 	// Should wait close to 0.5 seconds (2 requests per second = 0.5s between requests)
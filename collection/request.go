@@ -1,6 +1,8 @@
 package collection
 
 import (
+	"context"
+	"encoding/xml"
 	"fmt"
 	"net/url"
 	"strconv"
@@ -34,24 +36,74 @@ type CollectionOption func(params url.Values)
 //	    collection.WithStats(),
 //	    collection.WithMinRating(7))
 func Query(client *gogeek.Client, username string, opts ...CollectionOption) (*Collection, error) {
-	params := url.Values{}
-	params.Set("username", username)
+	return QueryContext(context.Background(), client, username, opts...)
+}
 
-	// Apply all options
-	for _, opt := range opts {
-		opt(params)
+// QueryContext is like Query but carries a context that can cancel the request
+// while it waits on the rate limiter, is in flight, or is backing off between retries.
+func QueryContext(ctx context.Context, client *gogeek.Client, username string, opts ...CollectionOption) (*Collection, error) {
+	var collection Collection
+	if err := request.FetchAndUnmarshalContext(ctx, client, queryURL(client, username, opts), &collection); err != nil {
+		return nil, err
 	}
 
-	queryURL := constants.CollectionEndpoint + "?" + params.Encode()
+	return &collection, nil
+}
+
+// QueryEach retrieves a user's collection like Query, but decodes the response
+// incrementally and calls fn with each item instead of holding the whole collection
+// in memory. This suits very large collections, particularly with WithStats.
+//
+// The returned Collection holds the collection's attributes, such as TotalItems, with
+// Items left empty. If fn returns an error, decoding stops and the error is returned.
+// Streamed responses bypass the client's cache.
+//
+// Example:
+//
+//	client := gogeek.NewClient()
+//	_, err := collection.QueryEach(client, "exampleuser", func(item collection.CollectionItem) error {
+//	    fmt.Println(item.Name)
+//	    return nil
+//	}, collection.WithStats())
+func QueryEach(client *gogeek.Client, username string, fn func(CollectionItem) error, opts ...CollectionOption) (*Collection, error) {
+	return QueryEachContext(context.Background(), client, username, fn, opts...)
+}
 
+// QueryEachContext is like QueryEach but carries a context that can cancel the request
+// while it waits on the rate limiter, is in flight, or is backing off between retries.
+func QueryEachContext(ctx context.Context, client *gogeek.Client, username string, fn func(CollectionItem) error, opts ...CollectionOption) (*Collection, error) {
 	var collection Collection
-	if err := request.FetchAndUnmarshal(client, queryURL, &collection); err != nil {
+	err := request.StreamContext(ctx, client, queryURL(client, username, opts), &collection, func(d *xml.Decoder, start xml.StartElement) error {
+		if start.Name.Local != "item" {
+			return d.Skip()
+		}
+
+		var item CollectionItem
+		if err := d.DecodeElement(&item, &start); err != nil {
+			return err
+		}
+		return fn(item)
+	})
+	if err != nil {
 		return nil, err
 	}
 
 	return &collection, nil
 }
 
+// queryURL builds the collection request URL for username with the given options applied
+func queryURL(client *gogeek.Client, username string, opts []CollectionOption) string {
+	params := url.Values{}
+	params.Set("username", username)
+
+	// Apply all options
+	for _, opt := range opts {
+		opt(params)
+	}
+
+	return client.Endpoint(constants.CollectionPath) + "?" + params.Encode()
+}
+
 // WithVersion adds version info for each item in the collection
 func WithVersion() CollectionOption {
 	return func(params url.Values) {
@@ -1,12 +1,14 @@
 package collection
 
 import (
+	"context"
 	"net/url"
 	"testing"
 	"time"
 
 	"github.com/kkjdaniel/gogeek/v2"
 	"github.com/kkjdaniel/gogeek/v2/constants"
+	"github.com/kkjdaniel/gogeek/v2/request"
 	"github.com/kkjdaniel/gogeek/v2/testutils"
 
 	"github.com/google/go-cmp/cmp"
@@ -241,3 +243,61 @@ func TestMultipleOptions(t *testing.T) {
 
 	assert.Equal(t, len(expected), len(params))
 }
+
+func TestQueryContext_ResponseInfo(t *testing.T) {
+	defer testutils.ActivateMocks()()
+
+	url := constants.CollectionEndpoint + "?username=testuser"
+	testutils.SetupMockResponder(t, url, mockDataFileValid)
+
+	var info gogeek.ResponseInfo
+	ctx := gogeek.ContextWithResponseInfo(context.Background(), &info)
+
+	client := gogeek.NewClient()
+	result, err := QueryContext(ctx, client, "testuser")
+
+	require.NoError(t, err, "QueryContext should not return an error")
+	require.NotNil(t, result, "Result should not be nil")
+	require.Equal(t, url, info.URL, "QueryContext should request the built URL with the caller's context")
+}
+
+func TestQuery_InvalidUsername(t *testing.T) {
+	defer testutils.ActivateMocks()()
+
+	url := constants.CollectionEndpoint + "?username=nobody"
+	testutils.SetupMockResponderWithBody(t, url,
+		`<errors><error><message>Invalid username specified</message></error></errors>`, 200)
+
+	client := gogeek.NewClient()
+	collection, err := Query(client, "nobody")
+
+	require.ErrorIs(t, err, request.ErrUserNotFound, "Invalid username should be reported as ErrUserNotFound")
+	require.Nil(t, collection, "Collection should be nil when BGG returns an error")
+}
+
+func TestQueryEach(t *testing.T) {
+	defer testutils.ActivateMocks()()
+
+	url := constants.CollectionEndpoint + "?stats=1&username=testuser"
+	testutils.SetupMockResponder(t, url, mockDataFileValid)
+
+	client := gogeek.NewClient()
+	expected, err := Query(client, "testuser", WithStats())
+	require.NoError(t, err, "Query should not return an error")
+
+	var items []CollectionItem
+	collection, err := QueryEach(client, "testuser", func(item CollectionItem) error {
+		items = append(items, item)
+		return nil
+	}, WithStats())
+	require.NoError(t, err, "QueryEach should not return an error")
+
+	if diff := cmp.Diff(expected.Items, items); diff != "" {
+		t.Errorf("Streamed items mismatch (-want +got):\n%s", diff)
+	}
+
+	expected.Items = nil
+	if diff := cmp.Diff(expected, collection); diff != "" {
+		t.Errorf("Collection attributes mismatch (-want +got):\n%s", diff)
+	}
+}
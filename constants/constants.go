@@ -4,16 +4,31 @@ const (
 	BGGBaseURL = "https://boardgamegeek.com/xmlapi2"
 )
 
+// Endpoint paths relative to the API base URL.
 const (
-	CollectionEndpoint = BGGBaseURL + "/collection"
-	FamilyEndpoint     = BGGBaseURL + "/family"
-	ForumEndpoint      = BGGBaseURL + "/forum"
-	ForumListEndpoint  = BGGBaseURL + "/forumlist"
-	GuildEndpoint      = BGGBaseURL + "/guild"
-	HotEndpoint        = BGGBaseURL + "/hot"
-	PlaysEndpoint      = BGGBaseURL + "/plays"
-	SearchEndpoint     = BGGBaseURL + "/search"
-	ThingEndpoint      = BGGBaseURL + "/thing"
-	ThreadEndpoint     = BGGBaseURL + "/thread"
-	UserEndpoint       = BGGBaseURL + "/user"
+	CollectionPath = "/collection"
+	FamilyPath     = "/family"
+	ForumPath      = "/forum"
+	ForumListPath  = "/forumlist"
+	GuildPath      = "/guild"
+	HotPath        = "/hot"
+	PlaysPath      = "/plays"
+	SearchPath     = "/search"
+	ThingPath      = "/thing"
+	ThreadPath     = "/thread"
+	UserPath       = "/user"
+)
+
+const (
+	CollectionEndpoint = BGGBaseURL + CollectionPath
+	FamilyEndpoint     = BGGBaseURL + FamilyPath
+	ForumEndpoint      = BGGBaseURL + ForumPath
+	ForumListEndpoint  = BGGBaseURL + ForumListPath
+	GuildEndpoint      = BGGBaseURL + GuildPath
+	HotEndpoint        = BGGBaseURL + HotPath
+	PlaysEndpoint      = BGGBaseURL + PlaysPath
+	SearchEndpoint     = BGGBaseURL + SearchPath
+	ThingEndpoint      = BGGBaseURL + ThingPath
+	ThreadEndpoint     = BGGBaseURL + ThreadPath
+	UserEndpoint       = BGGBaseURL + UserPath
 )
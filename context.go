@@ -0,0 +1,81 @@
+package gogeek
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+type contextKey int
+
+const (
+	queuePolicyKey contextKey = iota
+	responseInfoKey
+	bypassCacheKey
+	requestInfoKey
+)
+
+// ResponseInfo describes how a request to the BGG API was served.
+//
+// Pass a ResponseInfo to ContextWithResponseInfo and use the returned context with any
+// QueryContext function; it is filled in once the request completes, whether or not it succeeds.
+// It also captures the raw and repaired response bodies, so payloads can be archived and
+// fields the models don't capture can be extracted.
+type ResponseInfo struct {
+	// URL is the request URL with any credentials redacted
+	URL string
+	// StatusCode is the HTTP status code of the last response, or 0 if no response was received
+	StatusCode int
+	// Attempts is the number of HTTP requests made, including queued polls and retries
+	Attempts int
+	// Retries is the number of times the request was retried after a retryable status
+	Retries int
+	// QueuedFor is the total time spent waiting on BGG to process a queued (202) request
+	QueuedFor time.Duration
+	// CacheHit is true if the response was served from the client's cache
+	CacheHit bool
+	// Shared is true if the response was shared with a concurrent identical request
+	Shared bool
+	// Header is the header of the successful response, or nil if it was served from the cache
+	Header http.Header
+	// RawBody is the response body exactly as BGG sent it, or nil if no body was read.
+	// Bodies are not captured for streamed requests.
+	RawBody []byte
+	// Body is the response body after malformed XML was repaired, as it was decoded
+	Body []byte
+}
+
+// ContextWithResponseInfo returns a context that records details about the request into info
+func ContextWithResponseInfo(ctx context.Context, info *ResponseInfo) context.Context {
+	return context.WithValue(ctx, responseInfoKey, info)
+}
+
+// ResponseInfoFromContext returns the ResponseInfo registered with ContextWithResponseInfo, or nil
+func ResponseInfoFromContext(ctx context.Context) *ResponseInfo {
+	info, _ := ctx.Value(responseInfoKey).(*ResponseInfo)
+	return info
+}
+
+// ContextWithQueuePolicy returns a context that overrides the client's queue policy for a single call
+//
+// Example:
+//
+//	ctx := gogeek.ContextWithQueuePolicy(context.Background(), gogeek.QueuePolicy{
+//	    InitialDelay: 5 * time.Second,
+//	    MaxDelay:     15 * time.Second,
+//	    Multiplier:   1.5,
+//	    Timeout:      2 * time.Minute,
+//	})
+//	items, err := collection.QueryContext(ctx, client, "username")
+func ContextWithQueuePolicy(ctx context.Context, policy QueuePolicy) context.Context {
+	return context.WithValue(ctx, queuePolicyKey, policy)
+}
+
+// QueuePolicyFor returns the queue policy that applies to a call made with ctx:
+// the policy set with ContextWithQueuePolicy if any, otherwise the client's policy
+func (c *Client) QueuePolicyFor(ctx context.Context) QueuePolicy {
+	if policy, ok := ctx.Value(queuePolicyKey).(QueuePolicy); ok {
+		return policy
+	}
+	return c.queuePolicy
+}
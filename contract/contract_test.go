@@ -123,6 +123,57 @@ func TestContract_Thing_MultipleIDs(t *testing.T) {
 	assert.GreaterOrEqual(t, len(result.Items), 2, "should return multiple items")
 }
 
+func TestContract_Thing_Versions(t *testing.T) {
+	client := newClient(t)
+	url := fmt.Sprintf("%s?id=%d&stats=1&versions=1", constants.ThingEndpoint, catanThingID)
+
+	result, err := thing.Query(client, []int{catanThingID}, thing.WithVersions())
+	require.NoError(t, err, "thing.Query with versions should not error")
+	require.NotEmpty(t, result.Items, "should return the item")
+
+	versions := result.Items[0].Versions
+	require.NotEmpty(t, versions, "Catan should have versions")
+
+	withDimensions := false
+	for _, v := range versions {
+		assert.Equal(t, "boardgameversion", v.Type, "version should have the boardgameversion type")
+		assert.NotZero(t, v.ID, "version should have an ID")
+		assert.NotEmpty(t, v.Name, "version should have a name")
+		assert.NotEmpty(t, v.Links, "version should have links")
+		if v.Width.Value > 0 && v.Length.Value > 0 && v.Depth.Value > 0 {
+			withDimensions = true
+		}
+	}
+	assert.True(t, withDimensions, "at least one version should have box dimensions")
+
+	rawXML, err := fetchRawXML(client, url)
+	require.NoError(t, err, "fetching raw XML for coverage check")
+	assertFieldCoverage(t, rawXML, thing.Items{}, "thing-versions")
+}
+
+func TestContract_Thing_Marketplace(t *testing.T) {
+	client := newClient(t)
+	url := fmt.Sprintf("%s?id=%d&marketplace=1&stats=1", constants.ThingEndpoint, catanThingID)
+
+	result, err := thing.Query(client, []int{catanThingID}, thing.WithMarketplace())
+	require.NoError(t, err, "thing.Query with marketplace should not error")
+	require.NotEmpty(t, result.Items, "should return the item")
+
+	for _, listing := range result.Items[0].Marketplace {
+		listedAt, err := listing.ListedAt()
+		assert.NoError(t, err, "listing date should parse")
+		assert.False(t, listedAt.IsZero(), "listing should have a list date")
+		_, err = listing.Price.Amount()
+		assert.NoError(t, err, "listing price should parse")
+		assert.Len(t, listing.Price.Currency, 3, "listing currency should be an ISO 4217 code")
+		assert.True(t, listing.Condition.Value.Valid(), "listing condition %q should be a known condition", listing.Condition.Value)
+	}
+
+	rawXML, err := fetchRawXML(client, url)
+	require.NoError(t, err, "fetching raw XML for coverage check")
+	assertFieldCoverage(t, rawXML, thing.Items{}, "thing-marketplace")
+}
+
 func TestContract_Thing_Unranked(t *testing.T) {
 	client := newClient(t)
 	url := fmt.Sprintf("%s?id=%d&stats=1", constants.ThingEndpoint, unrankedThingID)
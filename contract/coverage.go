@@ -3,63 +3,29 @@
 package contract
 
 import (
+	"context"
 	"fmt"
-	"io"
-	"net/http"
 	"reflect"
 	"strings"
 	"testing"
-	"time"
 
 	"github.com/beevik/etree"
 	gogeek "github.com/kkjdaniel/gogeek/v2"
+	"github.com/kkjdaniel/gogeek/v2/request"
 )
 
-// fetchRawXML makes an authenticated, rate-limited request and returns the raw XML bytes.
+// fetchRawXML makes a request through the client, with its rate limiting, queue
+// polling and retries, and returns the raw XML bytes exactly as BGG sent them.
 func fetchRawXML(client *gogeek.Client, url string) ([]byte, error) {
-	client.Limiter().Take()
+	var info gogeek.ResponseInfo
+	ctx := gogeek.ContextWithResponseInfo(context.Background(), &info)
 
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
+	var discard struct{}
+	if err := request.FetchAndUnmarshalContext(ctx, client, url, &discard); err != nil {
 		return nil, err
 	}
 
-	switch client.AuthMode() {
-	case gogeek.AuthAPIKey:
-		req.Header.Set("Authorization", "Bearer "+client.APIKey())
-	case gogeek.AuthCookie:
-		req.Header.Set("Cookie", client.CookieString())
-	}
-
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	// Handle 202 (processing) with a single retry
-	if resp.StatusCode == http.StatusAccepted {
-		time.Sleep(3 * time.Second)
-		client.Limiter().Take()
-		req2, _ := http.NewRequest("GET", url, nil)
-		switch client.AuthMode() {
-		case gogeek.AuthAPIKey:
-			req2.Header.Set("Authorization", "Bearer "+client.APIKey())
-		case gogeek.AuthCookie:
-			req2.Header.Set("Cookie", client.CookieString())
-		}
-		resp, err = http.DefaultClient.Do(req2)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-	}
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
-	}
-
-	return io.ReadAll(resp.Body)
+	return info.RawBody, nil
 }
 
 // globalIgnored contains XML paths that appear in every BGG API response
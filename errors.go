@@ -0,0 +1,126 @@
+package gogeek
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// redactedValue replaces sensitive values when URLs are included in errors
+const redactedValue = "REDACTED"
+
+// sensitiveParams lists query parameters whose values are redacted by RedactURL
+var sensitiveParams = map[string]bool{
+	"apikey":   true,
+	"api_key":  true,
+	"key":      true,
+	"token":    true,
+	"password": true,
+	"session":  true,
+}
+
+// APIError describes a failed request to the BGG API.
+//
+// It wraps both a sentinel error describing the kind of failure (e.g. request.ErrUnexpectedStatusCode)
+// and the underlying cause, so errors.Is and errors.As work against either.
+type APIError struct {
+	// Err is the sentinel error describing the kind of failure
+	Err error
+	// Cause is the underlying error, if any (e.g. a transport error)
+	Cause error
+	// StatusCode is the HTTP status code of the last response, or 0 if no response was received
+	StatusCode int
+	// URL is the request URL with any credentials redacted
+	URL string
+	// Attempts is the number of HTTP requests made before giving up
+	Attempts int
+	// QueuedFor is the total time spent waiting on BGG to process a queued (202) request
+	QueuedFor time.Duration
+	// RetryAfter is the delay requested by the server's Retry-After header, if any
+	RetryAfter time.Duration
+	// Body is a truncated excerpt of the response body, if any
+	Body string
+	// Message is the error message BGG returned in an <error> or <errors> document, if any
+	Message string
+}
+
+// Error returns a description of the failure including the status code and request URL
+func (e *APIError) Error() string {
+	var b strings.Builder
+
+	if e.Err != nil {
+		b.WriteString(e.Err.Error())
+	} else {
+		b.WriteString("BGG API request failed")
+	}
+
+	if e.StatusCode != 0 && e.StatusCode != http.StatusOK {
+		fmt.Fprintf(&b, ": %d", e.StatusCode)
+	}
+
+	if e.Message != "" {
+		fmt.Fprintf(&b, ": %q", e.Message)
+	}
+
+	if e.Cause != nil {
+		fmt.Fprintf(&b, ": %v", e.Cause)
+	}
+
+	if e.URL != "" {
+		fmt.Fprintf(&b, " (GET %s, attempts: %d)", e.URL, e.Attempts)
+	}
+
+	return b.String()
+}
+
+// Unwrap returns the sentinel error and the underlying cause
+func (e *APIError) Unwrap() []error {
+	var errs []error
+	if e.Err != nil {
+		errs = append(errs, e.Err)
+	}
+	if e.Cause != nil {
+		errs = append(errs, e.Cause)
+	}
+	return errs
+}
+
+// RedactURL removes credentials from a URL so it can be safely logged or included in errors
+// User info passwords and the values of sensitive query parameters (e.g. apikey, token) are replaced.
+// If the URL can't be parsed, its query string and fragment are dropped, and it is replaced
+// entirely if it may contain user info.
+func RedactURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		stripped, _, _ := strings.Cut(rawURL, "?")
+		stripped, _, _ = strings.Cut(stripped, "#")
+		if strings.Contains(stripped, "@") {
+			return redactedValue
+		}
+		return stripped
+	}
+
+	if u.User != nil {
+		if _, hasPassword := u.User.Password(); hasPassword {
+			u.User = url.UserPassword(u.User.Username(), redactedValue)
+		}
+	}
+
+	if u.RawQuery != "" {
+		query := u.Query()
+		redacted := false
+		for key := range query {
+			if sensitiveParams[strings.ToLower(key)] {
+				query.Set(key, redactedValue)
+				redacted = true
+			}
+		}
+		if redacted {
+			u.RawQuery = query.Encode()
+		}
+	}
+
+	return u.String()
+}
@@ -1,6 +1,7 @@
 package family
 
 import (
+	"context"
 	"errors"
 	"fmt"
 
@@ -43,16 +44,22 @@ var ErrInvalidFamilyType = errors.New("invalid family type")
 //	}
 //	fmt.Printf("Family: %s (contains %d games)\n", family.Items[0].Name.Value, len(family.Items[0].Links))
 func Query(client *gogeek.Client, id int, familyType string) (*Family, error) {
+	return QueryContext(context.Background(), client, id, familyType)
+}
+
+// QueryContext is like Query but carries a context that can cancel the request
+// while it waits on the rate limiter, is in flight, or is backing off between retries.
+func QueryContext(ctx context.Context, client *gogeek.Client, id int, familyType string) (*Family, error) {
 	if !isValidFamilyType(familyType) {
 		return nil, fmt.Errorf("%w: %s (must be one of: %s, %s, %s)",
 			ErrInvalidFamilyType, familyType, RPG, RPGPeriodical, BoardGameFamily)
 	}
 
-	url := fmt.Sprintf("%s?id=%d&type=%s", constants.FamilyEndpoint, id, familyType)
+	url := fmt.Sprintf("%s?id=%d&type=%s", client.Endpoint(constants.FamilyPath), id, familyType)
 
 	var familyDetail Family
 
-	if err := request.FetchAndUnmarshal(client, url, &familyDetail); err != nil {
+	if err := request.FetchAndUnmarshalContext(ctx, client, url, &familyDetail); err != nil {
 		return nil, err
 	}
 
@@ -1,6 +1,7 @@
 package family
 
 import (
+	"context"
 	"testing"
 
 	"github.com/kkjdaniel/gogeek/v2"
@@ -66,3 +67,20 @@ func TestQueryFamily_Error(t *testing.T) {
 
 	testutils.TestRequestError(t, testURL, queryWrapper)
 }
+
+func TestQueryContext_ResponseInfo(t *testing.T) {
+	defer testutils.ActivateMocks()()
+
+	url := constants.FamilyEndpoint + "?id=12&type=" + BoardGameFamily
+	testutils.SetupMockResponder(t, url, mockDataFileValid)
+
+	var info gogeek.ResponseInfo
+	ctx := gogeek.ContextWithResponseInfo(context.Background(), &info)
+
+	client := gogeek.NewClient()
+	result, err := QueryContext(ctx, client, 12, BoardGameFamily)
+
+	require.NoError(t, err, "QueryContext should not return an error")
+	require.NotNil(t, result, "Result should not be nil")
+	require.Equal(t, url, info.URL, "QueryContext should request the built URL with the caller's context")
+}
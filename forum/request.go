@@ -1,6 +1,7 @@
 package forum
 
 import (
+	"context"
 	"net/url"
 	"strconv"
 
@@ -43,6 +44,12 @@ type ForumOption func(params url.Values)
 //	    log.Fatalf("Failed to get forum page 2: %v", err)
 //	}
 func Query(client *gogeek.Client, id int, opts ...ForumOption) (*Forum, error) {
+	return QueryContext(context.Background(), client, id, opts...)
+}
+
+// QueryContext is like Query but carries a context that can cancel the request
+// while it waits on the rate limiter, is in flight, or is backing off between retries.
+func QueryContext(ctx context.Context, client *gogeek.Client, id int, opts ...ForumOption) (*Forum, error) {
 	params := url.Values{}
 	params.Set("id", strconv.Itoa(id))
 
@@ -51,11 +58,11 @@ func Query(client *gogeek.Client, id int, opts ...ForumOption) (*Forum, error) {
 		opt(params)
 	}
 
-	queryURL := constants.ForumEndpoint + "?" + params.Encode()
+	queryURL := client.Endpoint(constants.ForumPath) + "?" + params.Encode()
 
 	var forumDetail Forum
 
-	if err := request.FetchAndUnmarshal(client, queryURL, &forumDetail); err != nil {
+	if err := request.FetchAndUnmarshalContext(ctx, client, queryURL, &forumDetail); err != nil {
 		return nil, err
 	}
 
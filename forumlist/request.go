@@ -1,6 +1,7 @@
 package forumlist
 
 import (
+	"context"
 	"errors"
 	"fmt"
 
@@ -42,16 +43,22 @@ var ErrInvalidForumListType = errors.New("invalid forum list type")
 //	}
 //	fmt.Printf("Found %d forums for this game\n", len(forums.Forums))
 func Query(client *gogeek.Client, id int, forumListType string) (*ForumList, error) {
+	return QueryContext(context.Background(), client, id, forumListType)
+}
+
+// QueryContext is like Query but carries a context that can cancel the request
+// while it waits on the rate limiter, is in flight, or is backing off between retries.
+func QueryContext(ctx context.Context, client *gogeek.Client, id int, forumListType string) (*ForumList, error) {
 	if !isValidForumListType(forumListType) {
 		return nil, fmt.Errorf("%w: %s (must be one of: %s, %s)",
 			ErrInvalidForumListType, forumListType, Thing, Family)
 	}
 
-	url := fmt.Sprintf("%s?id=%d&type=%s", constants.ForumListEndpoint, id, forumListType)
+	url := fmt.Sprintf("%s?id=%d&type=%s", client.Endpoint(constants.ForumListPath), id, forumListType)
 
 	var forumList ForumList
 
-	if err := request.FetchAndUnmarshal(client, url, &forumList); err != nil {
+	if err := request.FetchAndUnmarshalContext(ctx, client, url, &forumList); err != nil {
 		return nil, err
 	}
 
@@ -60,4 +67,4 @@ func Query(client *gogeek.Client, id int, forumListType string) (*ForumList, err
 
 func isValidForumListType(forumListType string) bool {
 	return forumListType == Thing || forumListType == Family
-}
\ No newline at end of file
+}
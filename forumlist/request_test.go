@@ -1,6 +1,7 @@
 package forumlist
 
 import (
+	"context"
 
 	"github.com/kkjdaniel/gogeek/v2"
 	"testing"
@@ -169,4 +170,21 @@ func TestQuery_Error(t *testing.T) {
 	}
 
 	testutils.TestRequestError(t, testURL, queryWrapper)
-}
\ No newline at end of file
+}
+
+func TestQueryContext_ResponseInfo(t *testing.T) {
+	defer testutils.ActivateMocks()()
+
+	url := constants.ForumListEndpoint + "?id=12&type=family"
+	testutils.SetupMockResponder(t, url, mockDataFileValidFamily)
+
+	var info gogeek.ResponseInfo
+	ctx := gogeek.ContextWithResponseInfo(context.Background(), &info)
+
+	client := gogeek.NewClient()
+	result, err := QueryContext(ctx, client, 12, Family)
+
+	require.NoError(t, err, "QueryContext should not return an error")
+	require.NotNil(t, result, "Result should not be nil")
+	require.Equal(t, url, info.URL, "QueryContext should request the built URL with the caller's context")
+}
@@ -4,13 +4,14 @@ go 1.21.3
 
 require (
 	github.com/beevik/etree v1.5.1
-	github.com/benbjohnson/clock v1.3.0 // indirect
-	github.com/clbanning/mxj v1.8.4 // indirect
+	github.com/clbanning/mxj v1.8.4
+	github.com/google/go-cmp v0.7.0
+	github.com/jarcoal/httpmock v1.3.1
+	github.com/stretchr/testify v1.10.0
+)
+
+require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
-	github.com/google/go-cmp v0.7.0 // indirect
-	github.com/jarcoal/httpmock v1.3.1 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
-	github.com/stretchr/testify v1.10.0 // indirect
-	go.uber.org/ratelimit v0.3.1 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
@@ -0,0 +1,187 @@
+package gogeektest
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kkjdaniel/gogeek/v2"
+)
+
+//go:embed fixtures/*.xml
+var fixtures embed.FS
+
+// BasePath is the path the server serves the XML API from, matching BGG's /xmlapi2
+const BasePath = "/xmlapi2"
+
+// Endpoints lists the names of the endpoints the server implements
+var Endpoints = []string{
+	"collection", "family", "forum", "forumlist", "guild", "hot",
+	"plays", "search", "thing", "thread", "user",
+}
+
+// Response is a scripted response served by the Server
+type Response struct {
+	StatusCode int
+	Header     http.Header
+	Body       string
+}
+
+// Queued returns a 202 response, which BGG sends while it prepares a request such as a collection
+func Queued() Response {
+	return Response{StatusCode: http.StatusAccepted}
+}
+
+// RateLimited returns a 429 response asking the client to retry after the given delay
+func RateLimited(retryAfter time.Duration) Response {
+	header := make(http.Header)
+	header.Set("Retry-After", strconv.Itoa(int(retryAfter.Seconds())))
+	return Response{StatusCode: http.StatusTooManyRequests, Header: header}
+}
+
+// ErrorDocument returns a 200 response containing an in-band BGG <errors> document with the given message
+func ErrorDocument(message string) Response {
+	return Response{
+		StatusCode: http.StatusOK,
+		Body:       fmt.Sprintf("<errors><error><message>%s</message></error></errors>", message),
+	}
+}
+
+// MalformedXML returns a 200 response whose body is truncated and cannot be parsed as XML
+func MalformedXML() Response {
+	return Response{StatusCode: http.StatusOK, Body: `<?xml version="1.0" encoding="utf-8"?><items><item id="1"><name value="Unclosed`}
+}
+
+// Server is a fake BGG XML API server for tests. It implements all eleven xmlapi2
+// endpoints from in-memory fixtures, serving the same fixture for an endpoint
+// whatever the query parameters. Scripted responses can be queued per endpoint to
+// simulate queueing, rate limiting, in-band errors and malformed XML.
+//
+// Example:
+//
+//	server := gogeektest.NewServer()
+//	defer server.Close()
+//
+//	server.Enqueue("collection", gogeektest.Queued())
+//	client := server.Client()
+//	collection, err := collection.Query(client, "username")
+type Server struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	fixtures map[string][]byte
+	scripts  map[string][]Response
+	requests []*http.Request
+}
+
+// NewServer starts a Server serving the built-in fixtures. Close it when finished.
+func NewServer() *Server {
+	s := &Server{
+		fixtures: make(map[string][]byte),
+		scripts:  make(map[string][]Response),
+	}
+
+	for _, endpoint := range Endpoints {
+		data, err := fixtures.ReadFile("fixtures/" + endpoint + ".xml")
+		if err != nil {
+			panic(fmt.Sprintf("gogeektest: missing fixture for %s: %v", endpoint, err))
+		}
+		s.fixtures[endpoint] = data
+	}
+
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// BaseURL returns the base URL to pass to gogeek.WithBaseURL
+func (s *Server) BaseURL() string {
+	return s.URL + BasePath
+}
+
+// Client returns a gogeek client that sends requests to the server without rate limiting
+// or retry delays, polling queued requests every millisecond. Any options given are applied
+// afterwards and take precedence.
+func (s *Server) Client(opts ...gogeek.ClientOption) *gogeek.Client {
+	return gogeek.NewClient(append([]gogeek.ClientOption{
+		gogeek.WithBaseURL(s.BaseURL()),
+		gogeek.WithRateLimiter(unlimited{}),
+		gogeek.WithRetryPolicy(gogeek.RetryPolicy{
+			MaxAttempts:       gogeek.DefaultRetryPolicy().MaxAttempts,
+			RetryableStatuses: gogeek.DefaultRetryPolicy().RetryableStatuses,
+		}),
+		gogeek.WithQueuePolicy(gogeek.QueuePolicy{
+			MaxPolls:     gogeek.DefaultQueuePolicy().MaxPolls,
+			InitialDelay: time.Millisecond,
+			MaxDelay:     time.Millisecond,
+		}),
+	}, opts...)...)
+}
+
+// SetFixture replaces the body served by endpoint (e.g., "thing") when no scripted response is queued
+func (s *Server) SetFixture(endpoint string, body []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.fixtures[endpoint] = body
+}
+
+// Enqueue adds scripted responses for endpoint (e.g., "collection"). Each request to the
+// endpoint consumes the next scripted response; once they run out the fixture is served.
+func (s *Server) Enqueue(endpoint string, responses ...Response) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.scripts[endpoint] = append(s.scripts[endpoint], responses...)
+}
+
+// Requests returns the requests the server has received, in order
+func (s *Server) Requests() []*http.Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*http.Request(nil), s.requests...)
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	endpoint := strings.TrimPrefix(r.URL.Path, BasePath+"/")
+	if r.Method != http.MethodGet || path.Dir(r.URL.Path) != BasePath {
+		http.NotFound(w, r)
+		return
+	}
+
+	s.mu.Lock()
+	s.requests = append(s.requests, r.Clone(context.Background()))
+
+	fixture, ok := s.fixtures[endpoint]
+	response := Response{StatusCode: http.StatusOK, Body: string(fixture)}
+	if script := s.scripts[endpoint]; len(script) > 0 {
+		response, s.scripts[endpoint] = script[0], script[1:]
+		ok = true
+	}
+	s.mu.Unlock()
+
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	for key, values := range response.Header {
+		w.Header()[key] = values
+	}
+	if response.Body != "" && w.Header().Get("Content-Type") == "" {
+		w.Header().Set("Content-Type", "text/xml; charset=utf-8")
+	}
+	w.WriteHeader(response.StatusCode)
+	w.Write([]byte(response.Body))
+}
+
+// unlimited is a gogeek.Limiter that never waits
+type unlimited struct{}
+
+func (unlimited) Wait(ctx context.Context) error {
+	return ctx.Err()
+}
@@ -0,0 +1,110 @@
+package gogeektest
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/kkjdaniel/gogeek/v2"
+	"github.com/kkjdaniel/gogeek/v2/collection"
+	"github.com/kkjdaniel/gogeek/v2/family"
+	"github.com/kkjdaniel/gogeek/v2/forum"
+	"github.com/kkjdaniel/gogeek/v2/forumlist"
+	"github.com/kkjdaniel/gogeek/v2/guild"
+	"github.com/kkjdaniel/gogeek/v2/hot"
+	"github.com/kkjdaniel/gogeek/v2/plays"
+	"github.com/kkjdaniel/gogeek/v2/request"
+	"github.com/kkjdaniel/gogeek/v2/search"
+	"github.com/kkjdaniel/gogeek/v2/thing"
+	"github.com/kkjdaniel/gogeek/v2/thread"
+	"github.com/kkjdaniel/gogeek/v2/user"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer_AllEndpoints(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	client := server.Client()
+	queries := map[string]func() (any, error){
+		"collection": func() (any, error) { return collection.Query(client, "someuser") },
+		"family":     func() (any, error) { return family.Query(client, 8374, family.BoardGameFamily) },
+		"forum":      func() (any, error) { return forum.Query(client, 19) },
+		"forumlist":  func() (any, error) { return forumlist.Query(client, 174430, "thing") },
+		"guild":      func() (any, error) { return guild.Query(client, 1) },
+		"hot":        func() (any, error) { return hot.Query(client, hot.ItemTypeBoardGame) },
+		"plays":      func() (any, error) { return plays.Query(client, "someuser") },
+		"search":     func() (any, error) { return search.Query(client, "catan") },
+		"thing":      func() (any, error) { return thing.Query(client, []int{13}) },
+		"thread":     func() (any, error) { return thread.Query(client, 1) },
+		"user":       func() (any, error) { return user.Query(client, "someuser") },
+	}
+	require.Len(t, queries, len(Endpoints), "Every endpoint should be tested")
+
+	for _, endpoint := range Endpoints {
+		t.Run(endpoint, func(t *testing.T) {
+			result, err := queries[endpoint]()
+			require.NoError(t, err, "Query should succeed against the fixture")
+			require.NotNil(t, result, "Query should return a result")
+		})
+	}
+
+	require.Len(t, server.Requests(), len(Endpoints), "Every request should be recorded")
+}
+
+func TestServer_ScriptedResponses(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	server.Enqueue("collection", Queued(), RateLimited(time.Minute))
+	client := server.Client(gogeek.WithAPIKey("test-key"))
+
+	var info gogeek.ResponseInfo
+	result, err := collection.QueryContext(gogeek.ContextWithResponseInfo(context.Background(), &info), client, "someuser")
+
+	require.NoError(t, err, "Query should succeed once the scripted responses are consumed")
+	require.NotEmpty(t, result.Items, "Fixture should be served after the scripted responses")
+	require.Equal(t, 3, info.Attempts, "Queued and rate limited responses should be retried")
+	require.Equal(t, 1, info.Retries, "Rate limited response should be retried")
+
+	requests := server.Requests()
+	require.Len(t, requests, 3, "Every attempt should reach the server")
+	require.Equal(t, "Bearer test-key", requests[0].Header.Get("Authorization"), "Client credentials should be sent")
+	require.Equal(t, "someuser", requests[0].URL.Query().Get("username"), "Query parameters should be sent")
+}
+
+func TestServer_ErrorDocument(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	server.Enqueue("user", ErrorDocument("Invalid username specified"))
+	_, err := user.Query(server.Client(), "nobody")
+
+	require.ErrorIs(t, err, request.ErrUserNotFound, "In-band error documents should be reported")
+}
+
+func TestServer_MalformedXML(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	server.Enqueue("thing", MalformedXML())
+	_, err := thing.Query(server.Client(), []int{13})
+
+	require.ErrorIs(t, err, request.ErrXMLParseError, "Malformed XML should fail to parse")
+}
+
+func TestServer_SetFixtureAndNotFound(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	server.SetFixture("hot", []byte(`<items><item id="42" rank="1"><name value="Custom"/></item></items>`))
+	items, err := hot.Query(server.Client(), hot.ItemTypeBoardGame)
+	require.NoError(t, err, "Query should succeed against the custom fixture")
+	require.Equal(t, "Custom", items.Items[0].Name.Value, "Custom fixture should be served")
+
+	resp, err := http.Get(server.URL + "/xmlapi2/unknown")
+	require.NoError(t, err, "Request should complete")
+	resp.Body.Close()
+	require.Equal(t, http.StatusNotFound, resp.StatusCode, "Unknown endpoints should return 404")
+}
@@ -1,6 +1,7 @@
 package guild
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/kkjdaniel/gogeek/v2"
@@ -31,11 +32,17 @@ import (
 //	}
 //	fmt.Printf("Guild name: %s (managed by %s)\n", guild.Name, guild.Manager)
 func Query(client *gogeek.Client, guildID int) (*Guild, error) {
-	url := fmt.Sprintf(constants.GuildEndpoint+"?id=%d", guildID)
+	return QueryContext(context.Background(), client, guildID)
+}
+
+// QueryContext is like Query but carries a context that can cancel the request
+// while it waits on the rate limiter, is in flight, or is backing off between retries.
+func QueryContext(ctx context.Context, client *gogeek.Client, guildID int) (*Guild, error) {
+	url := fmt.Sprintf("%s?id=%d", client.Endpoint(constants.GuildPath), guildID)
 
 	var guild Guild
 
-	if err := request.FetchAndUnmarshal(client, url, &guild); err != nil {
+	if err := request.FetchAndUnmarshalContext(ctx, client, url, &guild); err != nil {
 		return nil, err
 	}
 
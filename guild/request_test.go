@@ -1,6 +1,7 @@
 package guild
 
 import (
+	"context"
 
 	"github.com/kkjdaniel/gogeek/v2"
 	"testing"
@@ -58,3 +59,20 @@ func TestQuery_Error(t *testing.T) {
 
 	testutils.TestRequestError(t, testURL, queryWrapper)
 }
+
+func TestQueryContext_ResponseInfo(t *testing.T) {
+	defer testutils.ActivateMocks()()
+
+	url := constants.GuildEndpoint + "?id=1234"
+	testutils.SetupMockResponder(t, url, mockDataFileValid)
+
+	var info gogeek.ResponseInfo
+	ctx := gogeek.ContextWithResponseInfo(context.Background(), &info)
+
+	client := gogeek.NewClient()
+	result, err := QueryContext(ctx, client, 1234)
+
+	require.NoError(t, err, "QueryContext should not return an error")
+	require.NotNil(t, result, "Result should not be nil")
+	require.Equal(t, url, info.URL, "QueryContext should request the built URL with the caller's context")
+}
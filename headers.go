@@ -0,0 +1,60 @@
+package gogeek
+
+import (
+	"net/http"
+)
+
+// DefaultUserAgent is the User-Agent sent by clients that don't set their own with WithUserAgent
+const DefaultUserAgent = "gogeek/2 (+https://github.com/kkjdaniel/gogeek)"
+
+// UserAgent returns the User-Agent header sent with every request
+func (c *Client) UserAgent() string {
+	return c.userAgent
+}
+
+// Headers returns a copy of the extra headers sent with every request
+func (c *Client) Headers() http.Header {
+	return c.headers.Clone()
+}
+
+// Compression reports whether the client asks BGG for compressed responses
+func (c *Client) Compression() bool {
+	return c.compression
+}
+
+// WithUserAgent sets the User-Agent header sent with every request. BGG asks API
+// consumers to identify their application, ideally with contact details.
+//
+// Example:
+//
+//	client := gogeek.NewClient(gogeek.WithUserAgent("my-app/1.0 (+https://example.com/contact)"))
+func WithUserAgent(userAgent string) ClientOption {
+	return func(c *Client) {
+		if userAgent != "" {
+			c.userAgent = userAgent
+		}
+	}
+}
+
+// WithHeader adds a header sent with every request, replacing any earlier value for the same key
+// Authentication headers set with WithAPIKey or WithCookie take precedence
+func WithHeader(key, value string) ClientOption {
+	return func(c *Client) {
+		if c.headers == nil {
+			c.headers = make(http.Header)
+		}
+		c.headers.Set(key, value)
+	}
+}
+
+// WithCompression controls whether the client itself asks BGG for gzip or deflate compressed
+// responses, which are decompressed transparently. It is enabled by default.
+//
+// Disabling it only turns off the client's own negotiation: a transport that adds its own
+// Accept-Encoding header, as Go's default transport does for gzip, still receives compressed
+// responses and decompresses them itself.
+func WithCompression(enabled bool) ClientOption {
+	return func(c *Client) {
+		c.compression = enabled
+	}
+}
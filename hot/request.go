@@ -1,6 +1,7 @@
 package hot
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/kkjdaniel/gogeek/v2"
@@ -45,10 +46,16 @@ const (
 //	}
 //	fmt.Printf("Retrieved %d hot games. #1 is %s\n", len(hotGames.Items), hotGames.Items[0].Name.Value)
 func Query(client *gogeek.Client, itemType ItemType) (*HotItems, error) {
-	url := fmt.Sprintf(constants.HotEndpoint+"?type=%s", itemType)
+	return QueryContext(context.Background(), client, itemType)
+}
+
+// QueryContext is like Query but carries a context that can cancel the request
+// while it waits on the rate limiter, is in flight, or is backing off between retries.
+func QueryContext(ctx context.Context, client *gogeek.Client, itemType ItemType) (*HotItems, error) {
+	url := fmt.Sprintf("%s?type=%s", client.Endpoint(constants.HotPath), itemType)
 
 	var hotItems HotItems
-	if err := request.FetchAndUnmarshal(client, url, &hotItems); err != nil {
+	if err := request.FetchAndUnmarshalContext(ctx, client, url, &hotItems); err != nil {
 		return nil, err
 	}
 
@@ -0,0 +1,176 @@
+package instrumentation
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/kkjdaniel/gogeek/v2"
+	"github.com/kkjdaniel/gogeek/v2/request"
+)
+
+// Metric names recorded by the Observer
+const (
+	// RequestsMetric counts calls to the BGG API
+	RequestsMetric = "gogeek.requests"
+	// ErrorsMetric counts calls to the BGG API that failed
+	ErrorsMetric = "gogeek.errors"
+	// DurationMetric records the duration of calls to the BGG API in seconds
+	DurationMetric = "gogeek.request.duration"
+	// LimiterWaitMetric records the time spent waiting on rate limiters in seconds
+	LimiterWaitMetric = "gogeek.limiter.wait"
+)
+
+// Attribute is a key-value pair attached to a span or measurement
+type Attribute struct {
+	Key   string
+	Value any
+}
+
+// Tracer starts spans. It mirrors the subset of OpenTelemetry's trace.Tracer used by the Observer.
+type Tracer interface {
+	// Start creates a span and returns a context carrying it
+	Start(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span)
+}
+
+// Span is a single traced operation. It mirrors the subset of OpenTelemetry's trace.Span used by the Observer.
+type Span interface {
+	SetAttributes(attrs ...Attribute)
+	RecordError(err error)
+	End()
+}
+
+// Meter creates instruments. It mirrors the subset of OpenTelemetry's metric.Meter used by the Observer.
+type Meter interface {
+	Counter(name, description, unit string) Counter
+	Histogram(name, description, unit string) Histogram
+}
+
+// Counter records monotonically increasing values
+type Counter interface {
+	Add(ctx context.Context, n int64, attrs ...Attribute)
+}
+
+// Histogram records a distribution of values
+type Histogram interface {
+	Record(ctx context.Context, value float64, attrs ...Attribute)
+}
+
+// Observer is a gogeek.Observer that creates a span for every call to the BGG API
+// and records request, error, duration and rate limiter wait metrics.
+type Observer struct {
+	tracer      Tracer
+	requests    Counter
+	errors      Counter
+	duration    Histogram
+	limiterWait Histogram
+}
+
+var _ gogeek.Observer = (*Observer)(nil)
+
+// New creates an Observer that records spans with tracer and metrics with meter.
+// Either may be nil to disable tracing or metrics.
+//
+// Spans are named "gogeek <endpoint>" and carry the endpoint, redacted URL, number of
+// items requested, status code, attempts, retries, queue time and whether the
+// response came from the cache or was shared with a concurrent call.
+//
+// Example:
+//
+//	observer := instrumentation.New(tracer, meter)
+//	client := gogeek.NewClient(gogeek.WithObserver(observer))
+func New(tracer Tracer, meter Meter) *Observer {
+	o := &Observer{tracer: tracer}
+
+	if meter != nil {
+		o.requests = meter.Counter(RequestsMetric, "Number of calls to the BGG API", "{request}")
+		o.errors = meter.Counter(ErrorsMetric, "Number of failed calls to the BGG API", "{error}")
+		o.duration = meter.Histogram(DurationMetric, "Duration of calls to the BGG API", "s")
+		o.limiterWait = meter.Histogram(LimiterWaitMetric, "Time spent waiting on rate limiters", "s")
+	}
+
+	return o
+}
+
+// StartCall starts a span for the call and returns a function that ends it and records its metrics
+func (o *Observer) StartCall(ctx context.Context, call gogeek.CallInfo) (context.Context, func(gogeek.ResponseInfo, error)) {
+	start := time.Now()
+	endpoint := Attribute{Key: "gogeek.endpoint", Value: call.Endpoint}
+
+	var span Span
+	if o.tracer != nil {
+		ctx, span = o.tracer.Start(ctx, "gogeek "+call.Endpoint,
+			endpoint,
+			Attribute{Key: "url.full", Value: call.URL},
+			Attribute{Key: "gogeek.item_count", Value: call.ItemCount},
+		)
+	}
+
+	return ctx, func(info gogeek.ResponseInfo, err error) {
+		if span != nil {
+			span.SetAttributes(
+				Attribute{Key: "http.response.status_code", Value: info.StatusCode},
+				Attribute{Key: "gogeek.attempts", Value: info.Attempts},
+				Attribute{Key: "gogeek.retries", Value: info.Retries},
+				Attribute{Key: "gogeek.queue_time", Value: info.QueuedFor.Seconds()},
+				Attribute{Key: "gogeek.cache_hit", Value: info.CacheHit},
+				Attribute{Key: "gogeek.shared", Value: info.Shared},
+			)
+			if err != nil {
+				span.SetAttributes(Attribute{Key: "error.type", Value: errorType(err)})
+				span.RecordError(err)
+			}
+			span.End()
+		}
+
+		if o.requests != nil {
+			o.requests.Add(ctx, 1, endpoint, Attribute{Key: "gogeek.cache_hit", Value: info.CacheHit})
+			o.duration.Record(ctx, time.Since(start).Seconds(), endpoint)
+			if err != nil {
+				o.errors.Add(ctx, 1, endpoint, Attribute{Key: "error.type", Value: errorType(err)})
+			}
+		}
+	}
+}
+
+// ObserveLimiterWait records the time spent waiting on rate limiters
+func (o *Observer) ObserveLimiterWait(ctx context.Context, endpoint string, wait time.Duration) {
+	if o.limiterWait != nil {
+		o.limiterWait.Record(ctx, wait.Seconds(), Attribute{Key: "gogeek.endpoint", Value: endpoint})
+	}
+}
+
+// errorType returns a low-cardinality description of err for use as an attribute
+func errorType(err error) string {
+	var apiErr *gogeek.APIError
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	case errors.Is(err, context.DeadlineExceeded):
+		return "deadline_exceeded"
+	case errors.Is(err, gogeek.ErrCircuitOpen):
+		return "circuit_open"
+	case errors.Is(err, request.ErrQueueTimeout):
+		return "queue_timeout"
+	case errors.Is(err, request.ErrMaxRetriesExceeded):
+		return "max_retries_exceeded"
+	case errors.Is(err, request.ErrUserNotFound):
+		return "user_not_found"
+	case errors.Is(err, request.ErrItemNotFound):
+		return "item_not_found"
+	case errors.Is(err, request.ErrInvalidParameter):
+		return "invalid_parameter"
+	case errors.Is(err, request.ErrBGGError):
+		return "bgg_error"
+	case errors.Is(err, request.ErrHTTPError):
+		return "http_error"
+	case errors.As(err, &apiErr) && apiErr.StatusCode != 0:
+		return strconv.Itoa(apiErr.StatusCode)
+	case errors.Is(err, request.ErrXMLParseError), errors.Is(err, request.ErrRegenerateError), errors.Is(err, request.ErrUnmarshalError):
+		return "decode_error"
+	default:
+		return "other"
+	}
+}
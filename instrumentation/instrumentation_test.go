@@ -0,0 +1,178 @@
+package instrumentation
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/kkjdaniel/gogeek/v2"
+	"github.com/kkjdaniel/gogeek/v2/request"
+	"github.com/stretchr/testify/require"
+)
+
+type spanKey struct{}
+
+type fakeSpan struct {
+	name  string
+	attrs map[string]any
+	err   error
+	ended bool
+}
+
+func (s *fakeSpan) SetAttributes(attrs ...Attribute) {
+	for _, attr := range attrs {
+		s.attrs[attr.Key] = attr.Value
+	}
+}
+
+func (s *fakeSpan) RecordError(err error) { s.err = err }
+func (s *fakeSpan) End()                  { s.ended = true }
+
+type fakeTracer struct {
+	spans []*fakeSpan
+}
+
+func (t *fakeTracer) Start(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span) {
+	span := &fakeSpan{name: name, attrs: make(map[string]any)}
+	span.SetAttributes(attrs...)
+	t.spans = append(t.spans, span)
+	return context.WithValue(ctx, spanKey{}, span), span
+}
+
+type measurement struct {
+	name  string
+	value float64
+	attrs map[string]any
+}
+
+type fakeMeter struct {
+	mu           sync.Mutex
+	measurements []measurement
+}
+
+type fakeInstrument struct {
+	meter *fakeMeter
+	name  string
+}
+
+func (m *fakeMeter) Counter(name, description, unit string) Counter {
+	return fakeInstrument{meter: m, name: name}
+}
+
+func (m *fakeMeter) Histogram(name, description, unit string) Histogram {
+	return fakeInstrument{meter: m, name: name}
+}
+
+func (i fakeInstrument) Add(ctx context.Context, n int64, attrs ...Attribute) {
+	i.Record(ctx, float64(n), attrs...)
+}
+
+func (i fakeInstrument) Record(ctx context.Context, value float64, attrs ...Attribute) {
+	m := measurement{name: i.name, value: value, attrs: make(map[string]any)}
+	for _, attr := range attrs {
+		m.attrs[attr.Key] = attr.Value
+	}
+
+	i.meter.mu.Lock()
+	defer i.meter.mu.Unlock()
+	i.meter.measurements = append(i.meter.measurements, m)
+}
+
+func (m *fakeMeter) named(name string) []measurement {
+	var found []measurement
+	for _, measurement := range m.measurements {
+		if measurement.name == name {
+			found = append(found, measurement)
+		}
+	}
+	return found
+}
+
+func TestObserver_Success(t *testing.T) {
+	testURL := "https://example.com/xmlapi2/thing?id=1,2,3"
+	transport := httpmock.NewMockTransport()
+	transport.RegisterResponder("GET", testURL, httpmock.ResponderFromMultipleResponses([]*http.Response{
+		httpmock.NewStringResponse(http.StatusTooManyRequests, ""),
+		httpmock.NewStringResponse(http.StatusOK, `<items><item id="1"/></items>`),
+	}))
+
+	var spanInRequest any
+	tracer := &fakeTracer{}
+	meter := &fakeMeter{}
+	client := gogeek.NewClient(
+		gogeek.WithTransport(transport),
+		gogeek.WithObserver(New(tracer, meter)),
+		gogeek.WithRetryPolicy(gogeek.RetryPolicy{MaxAttempts: 2, RetryableStatuses: []int{http.StatusTooManyRequests}}),
+		gogeek.WithMiddleware(func(next gogeek.Doer) gogeek.Doer {
+			return gogeek.DoerFunc(func(req *http.Request) (*http.Response, error) {
+				spanInRequest = req.Context().Value(spanKey{})
+				return next.Do(req)
+			})
+		}),
+	)
+
+	var result struct{}
+	err := request.FetchAndUnmarshal(client, testURL, &result)
+	require.NoError(t, err, "FetchAndUnmarshal should succeed")
+
+	require.Len(t, tracer.spans, 1, "One span should be started per call")
+	span := tracer.spans[0]
+	require.Equal(t, "gogeek thing", span.name, "Span should be named after the endpoint")
+	require.True(t, span.ended, "Span should be ended")
+	require.Nil(t, span.err, "Span should not record an error")
+	require.Same(t, span, spanInRequest, "HTTP requests should carry the call's span")
+	require.Equal(t, map[string]any{
+		"gogeek.endpoint":           "thing",
+		"url.full":                  testURL,
+		"gogeek.item_count":         3,
+		"http.response.status_code": http.StatusOK,
+		"gogeek.attempts":           2,
+		"gogeek.retries":            1,
+		"gogeek.queue_time":         0.0,
+		"gogeek.cache_hit":          false,
+		"gogeek.shared":             false,
+	}, span.attrs, "Span should describe the call")
+
+	require.Len(t, meter.named(RequestsMetric), 1, "Requests should be counted")
+	require.Len(t, meter.named(DurationMetric), 1, "Duration should be recorded")
+	require.Len(t, meter.named(LimiterWaitMetric), 2, "Limiter waits should be recorded for every attempt")
+	require.Empty(t, meter.named(ErrorsMetric), "No errors should be counted")
+}
+
+func TestObserver_Error(t *testing.T) {
+	testURL := "https://example.com/xmlapi2/collection?username=nobody"
+	transport := httpmock.NewMockTransport()
+	transport.RegisterResponder("GET", testURL, httpmock.NewStringResponder(http.StatusOK,
+		`<errors><error><message>Invalid username specified</message></error></errors>`))
+
+	tracer := &fakeTracer{}
+	meter := &fakeMeter{}
+	client := gogeek.NewClient(gogeek.WithTransport(transport), gogeek.WithObserver(New(tracer, meter)))
+
+	var result struct{}
+	err := request.FetchAndUnmarshal(client, testURL, &result)
+	require.ErrorIs(t, err, request.ErrUserNotFound, "FetchAndUnmarshal should fail")
+
+	require.Len(t, tracer.spans, 1, "One span should be started per call")
+	require.ErrorIs(t, tracer.spans[0].err, request.ErrUserNotFound, "Span should record the error")
+	require.Equal(t, "user_not_found", tracer.spans[0].attrs["error.type"], "Span should classify the error")
+	require.Equal(t, 0, tracer.spans[0].attrs["gogeek.item_count"], "Calls without IDs should have no item count")
+
+	errs := meter.named(ErrorsMetric)
+	require.Len(t, errs, 1, "Errors should be counted")
+	require.Equal(t, "user_not_found", errs[0].attrs["error.type"], "Errors should be classified")
+	require.Equal(t, "collection", errs[0].attrs["gogeek.endpoint"], "Errors should carry the endpoint")
+}
+
+func TestObserver_NilTracerAndMeter(t *testing.T) {
+	observer := New(nil, nil)
+
+	ctx, end := observer.StartCall(context.Background(), gogeek.CallInfo{Endpoint: "thing"})
+	require.NotNil(t, ctx, "Context should be returned without a tracer")
+
+	observer.ObserveLimiterWait(ctx, "thing", time.Second)
+	end(gogeek.ResponseInfo{}, nil)
+}
@@ -0,0 +1,19 @@
+package gogeek
+
+import (
+	"log/slog"
+)
+
+// Logger returns the structured logger for this client, or nil if logging is disabled
+func (c *Client) Logger() *slog.Logger {
+	return c.logger
+}
+
+// WithLogger configures the client to emit structured events for each call to logger,
+// including queued polls, retries, XML sanitisation and fallback parsing.
+// URLs are logged with credentials redacted. Logging is disabled by default.
+func WithLogger(logger *slog.Logger) ClientOption {
+	return func(c *Client) {
+		c.logger = logger
+	}
+}
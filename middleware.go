@@ -0,0 +1,133 @@
+package gogeek
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// Doer sends an HTTP request to the BGG API and returns the response
+// *http.Client satisfies Doer
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// DoerFunc adapts an ordinary function to a Doer
+type DoerFunc func(req *http.Request) (*http.Response, error)
+
+// Do calls f(req)
+func (f DoerFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Middleware wraps a Doer with additional behaviour such as logging, metrics, header injection or request rewriting.
+//
+// Middlewares see every HTTP attempt, including queued polls and retries. Details of the
+// attempt are available from the request's context with RequestInfoFromContext.
+//
+// Example:
+//
+//	userAgent := func(next gogeek.Doer) gogeek.Doer {
+//	    return gogeek.DoerFunc(func(req *http.Request) (*http.Response, error) {
+//	        req.Header.Set("X-Request-Source", "my-app")
+//	        return next.Do(req)
+//	    })
+//	}
+//	client := gogeek.NewClient(gogeek.WithMiddleware(userAgent))
+type Middleware func(next Doer) Doer
+
+// RequestInfo describes a single HTTP attempt made to the BGG API
+type RequestInfo struct {
+	// Endpoint is the name of the API endpoint (e.g., "thing", "collection")
+	Endpoint string
+	// URL is the request URL with any credentials redacted
+	URL string
+	// Attempt is the attempt number for this call, starting at 1
+	Attempt int
+}
+
+// ContextWithRequestInfo returns a context carrying details of an HTTP attempt
+// It is used by the request package to describe each attempt to middlewares
+func ContextWithRequestInfo(ctx context.Context, info RequestInfo) context.Context {
+	return context.WithValue(ctx, requestInfoKey, info)
+}
+
+// RequestInfoFromContext returns the details of the HTTP attempt carried by ctx
+func RequestInfoFromContext(ctx context.Context) (RequestInfo, bool) {
+	info, ok := ctx.Value(requestInfoKey).(RequestInfo)
+	return info, ok
+}
+
+// Doer returns the client's HTTP client wrapped in its middleware chain
+// The first middleware passed to WithMiddleware is the outermost, and the client's
+// circuit breaker, if any, wraps them all
+func (c *Client) Doer() Doer {
+	var doer Doer = c.httpClient
+	for i := len(c.middleware) - 1; i >= 0; i-- {
+		doer = c.middleware[i](doer)
+	}
+	if c.breaker != nil {
+		doer = c.breaker.middleware(doer)
+	}
+	return doer
+}
+
+// WithMiddleware adds middlewares around every HTTP request the client sends
+// Middlewares run in the order given, so the first sees the request first and the response last
+func WithMiddleware(middleware ...Middleware) ClientOption {
+	return func(c *Client) {
+		c.middleware = append(c.middleware, middleware...)
+	}
+}
+
+// LoggingMiddleware logs every HTTP attempt to logger at debug level, and failed attempts at warn level
+func LoggingMiddleware(logger *slog.Logger) Middleware {
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			info, _ := RequestInfoFromContext(req.Context())
+			start := time.Now()
+
+			resp, err := next.Do(req)
+
+			attrs := []any{
+				slog.String("endpoint", info.Endpoint),
+				slog.String("url", RedactURL(req.URL.String())),
+				slog.Int("attempt", info.Attempt),
+				slog.Duration("duration", time.Since(start)),
+			}
+
+			switch {
+			case err != nil:
+				logger.WarnContext(req.Context(), "BGG request failed", append(attrs, slog.Any("error", err))...)
+			case resp.StatusCode >= http.StatusBadRequest:
+				logger.WarnContext(req.Context(), "BGG request returned an error status", append(attrs, slog.Int("status", resp.StatusCode))...)
+			default:
+				logger.DebugContext(req.Context(), "BGG request completed", append(attrs, slog.Int("status", resp.StatusCode))...)
+			}
+
+			return resp, err
+		})
+	}
+}
+
+// LatencyMiddleware calls observe with the duration of every HTTP attempt
+// statusCode is 0 and err is set if no response was received
+func LatencyMiddleware(observe func(info RequestInfo, statusCode int, duration time.Duration, err error)) Middleware {
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			info, _ := RequestInfoFromContext(req.Context())
+			start := time.Now()
+
+			resp, err := next.Do(req)
+
+			statusCode := 0
+			if resp != nil {
+				statusCode = resp.StatusCode
+			}
+			observe(info, statusCode, time.Since(start), err)
+
+			return resp, err
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package gogeek
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func okDoer(statusCode int) Doer {
+	return DoerFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: statusCode, Body: http.NoBody, Request: req}, nil
+	})
+}
+
+func newTestRequest(t *testing.T) *http.Request {
+	req, err := http.NewRequest("GET", "https://boardgamegeek.com/xmlapi2/thing?id=13", nil)
+	require.NoError(t, err)
+
+	ctx := ContextWithRequestInfo(req.Context(), RequestInfo{Endpoint: "thing", URL: req.URL.String(), Attempt: 2})
+	return req.WithContext(ctx)
+}
+
+func TestDoer_MiddlewareOrder(t *testing.T) {
+	var order []string
+	record := func(name string) Middleware {
+		return func(next Doer) Doer {
+			return DoerFunc(func(req *http.Request) (*http.Response, error) {
+				order = append(order, name+" request")
+				resp, err := next.Do(req)
+				order = append(order, name+" response")
+				return resp, err
+			})
+		}
+	}
+
+	client := NewClient(
+		WithHTTPClient(&http.Client{Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			order = append(order, "transport")
+			return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody, Request: req}, nil
+		})}),
+		WithMiddleware(record("first")),
+		WithMiddleware(record("second")),
+	)
+
+	_, err := client.Doer().Do(newTestRequest(t))
+	require.NoError(t, err)
+	require.Equal(t, []string{"first request", "second request", "transport", "second response", "first response"}, order,
+		"Middlewares should run in the order they were added")
+}
+
+func TestDoer_NoMiddleware(t *testing.T) {
+	client := NewClient()
+	require.Equal(t, http.DefaultClient, client.Doer(), "Without middleware the HTTP client should be used directly")
+}
+
+func TestRequestInfoFromContext(t *testing.T) {
+	_, ok := RequestInfoFromContext(newTestRequest(t).Context())
+	require.True(t, ok, "Request info should be available from the request context")
+
+	req, _ := http.NewRequest("GET", "https://boardgamegeek.com/xmlapi2/hot", nil)
+	_, ok = RequestInfoFromContext(req.Context())
+	require.False(t, ok, "Request info should not be available when not set")
+}
+
+func TestLoggingMiddleware(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	_, err := LoggingMiddleware(logger)(okDoer(http.StatusOK)).Do(newTestRequest(t))
+	require.NoError(t, err)
+
+	output := buf.String()
+	require.Contains(t, output, "level=DEBUG", "Successful requests should be logged at debug level")
+	require.Contains(t, output, "endpoint=thing", "Log should include the endpoint")
+	require.Contains(t, output, "attempt=2", "Log should include the attempt")
+	require.Contains(t, output, "status=200", "Log should include the status")
+
+	buf.Reset()
+	_, err = LoggingMiddleware(logger)(okDoer(http.StatusTooManyRequests)).Do(newTestRequest(t))
+	require.NoError(t, err)
+	require.Contains(t, buf.String(), "level=WARN", "Error statuses should be logged at warn level")
+
+	buf.Reset()
+	failing := DoerFunc(func(req *http.Request) (*http.Response, error) {
+		return nil, errors.New("connection refused")
+	})
+	_, err = LoggingMiddleware(logger)(failing).Do(newTestRequest(t))
+	require.Error(t, err)
+	require.True(t, strings.Contains(buf.String(), "connection refused"), "Transport errors should be logged")
+}
+
+func TestLatencyMiddleware(t *testing.T) {
+	var observed RequestInfo
+	var observedStatus int
+	var observedDuration time.Duration
+
+	slow := DoerFunc(func(req *http.Request) (*http.Response, error) {
+		time.Sleep(20 * time.Millisecond)
+		return okDoer(http.StatusOK).Do(req)
+	})
+	middleware := LatencyMiddleware(func(info RequestInfo, statusCode int, duration time.Duration, err error) {
+		observed = info
+		observedStatus = statusCode
+		observedDuration = duration
+	})
+
+	_, err := middleware(slow).Do(newTestRequest(t))
+	require.NoError(t, err)
+	require.Equal(t, "thing", observed.Endpoint, "Latency should be reported with the endpoint")
+	require.Equal(t, http.StatusOK, observedStatus, "Latency should be reported with the status")
+	require.GreaterOrEqual(t, observedDuration, 20*time.Millisecond, "Latency should be measured")
+}
+
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
@@ -0,0 +1,48 @@
+package gogeek
+
+import (
+	"context"
+	"time"
+)
+
+// Observer receives telemetry about calls made to the BGG API, such as tracing spans and metrics.
+//
+// The instrumentation package provides an Observer backed by small tracing and metrics
+// interfaces that can be adapted to OpenTelemetry without the core module depending on it.
+// Implementations must be safe for concurrent use.
+type Observer interface {
+	// StartCall is called when a call to the BGG API begins. The returned context is used for
+	// the rest of the call, including every HTTP attempt, so middlewares and transports see it.
+	// end is called exactly once when the call completes, with its outcome and any error.
+	StartCall(ctx context.Context, call CallInfo) (_ context.Context, end func(info ResponseInfo, err error))
+
+	// ObserveLimiterWait is called after each wait on the client's rate limiters
+	ObserveLimiterWait(ctx context.Context, endpoint string, wait time.Duration)
+}
+
+// CallInfo describes a call to the BGG API as it begins
+type CallInfo struct {
+	// Endpoint is the name of the API endpoint (e.g., "thing", "collection")
+	Endpoint string
+	// URL is the request URL with any credentials redacted
+	URL string
+	// ItemCount is the number of IDs requested, or 0 for calls that don't request items by ID
+	ItemCount int
+}
+
+// Observer returns the observer notified of every call made by this client, or nil if none is set
+func (c *Client) Observer() Observer {
+	return c.observer
+}
+
+// WithObserver configures the client to report every call to observer
+//
+// Example:
+//
+//	observer := instrumentation.New(tracer, meter)
+//	client := gogeek.NewClient(gogeek.WithObserver(observer))
+func WithObserver(observer Observer) ClientOption {
+	return func(c *Client) {
+		c.observer = observer
+	}
+}
@@ -1,13 +1,32 @@
 package plays
 
 import (
-	"fmt"
+	"context"
+	"encoding/xml"
+	"net/url"
+	"strconv"
 
 	"github.com/kkjdaniel/gogeek/v2"
 	"github.com/kkjdaniel/gogeek/v2/constants"
 	"github.com/kkjdaniel/gogeek/v2/request"
 )
 
+// playsPerPage is the number of plays BGG returns in each page of a play history
+const playsPerPage = 100
+
+// PlaysOption represents an option for customising plays queries
+type PlaysOption func(params url.Values)
+
+// WithPage selects the page of plays to retrieve, starting at 1
+// BGG returns up to 100 plays per page
+func WithPage(page int) PlaysOption {
+	return func(params url.Values) {
+		if page >= 1 {
+			params.Set("page", strconv.Itoa(page))
+		}
+	}
+}
+
 // Query retrieves play information for a specific BoardGameGeek user.
 //
 // The function accepts a BGG username and returns a structured representation
@@ -17,9 +36,10 @@ import (
 // Parameters:
 //   - client: A GoGeek client configured with optional authentication
 //   - username: A string containing the BGG username whose play history to retrieve
+//   - opts: Optional parameters, such as WithPage to select a page of plays
 //
 // Returns:
-//   - *Plays: A pointer to a Plays struct containing the user's play information
+//   - *Plays: A pointer to a Plays struct containing a page of the user's play information
 //   - error: An error if the API request fails or if the response cannot be parsed
 //
 // Example:
@@ -30,14 +50,95 @@ import (
 //	    log.Fatalf("Failed to retrieve plays: %v", err)
 //	}
 //	fmt.Printf("Found %d plays for user %s\n", plays.Total, plays.Username)
-func Query(client *gogeek.Client, username string) (*Plays, error) {
-	url := fmt.Sprintf(constants.PlaysEndpoint+"?username=%s", username)
+func Query(client *gogeek.Client, username string, opts ...PlaysOption) (*Plays, error) {
+	return QueryContext(context.Background(), client, username, opts...)
+}
 
+// QueryContext is like Query but carries a context that can cancel the request
+// while it waits on the rate limiter, is in flight, or is backing off between retries.
+func QueryContext(ctx context.Context, client *gogeek.Client, username string, opts ...PlaysOption) (*Plays, error) {
 	var plays Plays
 
-	if err := request.FetchAndUnmarshal(client, url, &plays); err != nil {
+	if err := request.FetchAndUnmarshalContext(ctx, client, queryURL(client, username, opts), &plays); err != nil {
 		return nil, err
 	}
 
 	return &plays, nil
 }
+
+// QueryEach retrieves a user's whole play history, decoding each page incrementally and
+// calling fn with each play instead of holding every play in memory. Pages are requested
+// one at a time under the client's rate limiter, starting from the page given with
+// WithPage, if any, until every play has been read.
+//
+// The returned Plays holds the play history's attributes, such as Total, from the last
+// page read, with Plays left empty. If fn returns an error, decoding stops and the error
+// is returned. Streamed responses bypass the client's cache.
+//
+// Example:
+//
+//	client := gogeek.NewClient()
+//	history, err := plays.QueryEach(client, "exampleuser", func(play plays.Play) error {
+//	    fmt.Printf("%s: %s\n", play.Date, play.Item.Name)
+//	    return nil
+//	})
+func QueryEach(client *gogeek.Client, username string, fn func(Play) error, opts ...PlaysOption) (*Plays, error) {
+	return QueryEachContext(context.Background(), client, username, fn, opts...)
+}
+
+// QueryEachContext is like QueryEach but carries a context that can cancel the requests
+// while they wait on the rate limiter, are in flight, or are backing off between retries.
+func QueryEachContext(ctx context.Context, client *gogeek.Client, username string, fn func(Play) error, opts ...PlaysOption) (*Plays, error) {
+	page := 1
+	if params := queryParams(username, opts); params.Get("page") != "" {
+		page, _ = strconv.Atoi(params.Get("page"))
+	}
+
+	for {
+		var plays Plays
+		count := 0
+		pageURL := queryURL(client, username, append(opts[:len(opts):len(opts)], WithPage(page)))
+		err := request.StreamContext(ctx, client, pageURL, &plays, func(d *xml.Decoder, start xml.StartElement) error {
+			if start.Name.Local != "play" {
+				return d.Skip()
+			}
+
+			var play Play
+			if err := d.DecodeElement(&play, &start); err != nil {
+				return err
+			}
+			count++
+			return fn(play)
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if count == 0 || page*playsPerPage >= plays.Total {
+			return &plays, nil
+		}
+		page++
+	}
+}
+
+// queryParams returns the query parameters for username's plays with the given options applied
+func queryParams(username string, opts []PlaysOption) url.Values {
+	params := url.Values{}
+	params.Set("username", username)
+
+	for _, opt := range opts {
+		opt(params)
+	}
+
+	// BGG returns the first page by default, so leave it out to share cache entries
+	if params.Get("page") == "1" {
+		params.Del("page")
+	}
+
+	return params
+}
+
+// queryURL builds the plays request URL for username with the given options applied
+func queryURL(client *gogeek.Client, username string, opts []PlaysOption) string {
+	return client.Endpoint(constants.PlaysPath) + "?" + queryParams(username, opts).Encode()
+}
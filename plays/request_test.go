@@ -1,6 +1,12 @@
 package plays
 
 import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
 
 	"github.com/kkjdaniel/gogeek/v2"
 	"testing"
@@ -89,3 +95,126 @@ func TestQuery_Error(t *testing.T) {
 
 	testutils.TestRequestError(t, testURL, queryWrapper)
 }
+
+func TestQueryContext_ResponseInfo(t *testing.T) {
+	defer testutils.ActivateMocks()()
+
+	url := constants.PlaysEndpoint + "?username=example_user"
+	testutils.SetupMockResponder(t, url, mockDataFileValid)
+
+	var info gogeek.ResponseInfo
+	ctx := gogeek.ContextWithResponseInfo(context.Background(), &info)
+
+	client := gogeek.NewClient()
+	result, err := QueryContext(ctx, client, "example_user")
+
+	require.NoError(t, err, "QueryContext should not return an error")
+	require.NotNil(t, result, "Result should not be nil")
+	require.Equal(t, url, info.URL, "QueryContext should request the built URL with the caller's context")
+}
+
+func TestQueryEach(t *testing.T) {
+	defer testutils.ActivateMocks()()
+
+	url := constants.PlaysEndpoint + "?username=example_user"
+	testutils.SetupMockResponder(t, url, mockDataFileValid)
+
+	client := gogeek.NewClient()
+	expected, err := Query(client, "example_user")
+	require.NoError(t, err, "Query should not return an error")
+
+	var streamed []Play
+	plays, err := QueryEach(client, "example_user", func(play Play) error {
+		streamed = append(streamed, play)
+		return nil
+	})
+	require.NoError(t, err, "QueryEach should not return an error")
+
+	if diff := cmp.Diff(expected.Plays, streamed); diff != "" {
+		t.Errorf("Streamed plays mismatch (-want +got):\n%s", diff)
+	}
+
+	expected.Plays = nil
+	if diff := cmp.Diff(expected, plays); diff != "" {
+		t.Errorf("Plays attributes mismatch (-want +got):\n%s", diff)
+	}
+}
+
+// pagedPlaysServer serves total plays for username in pages of 100, recording each request's query
+func pagedPlaysServer(t *testing.T, username string, total int) (*httptest.Server, *[]string) {
+	t.Helper()
+
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.RawQuery)
+		if r.URL.Query().Get("username") != username {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		page := 1
+		if value := r.URL.Query().Get("page"); value != "" {
+			page, _ = strconv.Atoi(value)
+		}
+
+		var body strings.Builder
+		fmt.Fprintf(&body, `<plays username="%s" userid="1" total="%d" page="%d">`, username, total, page)
+		for id := (page-1)*playsPerPage + 1; id <= page*playsPerPage && id <= total; id++ {
+			fmt.Fprintf(&body, `<play id="%d" date="2024-01-01" quantity="1"><item name="Game" objecttype="thing" objectid="13"/></play>`, id)
+		}
+		body.WriteString(`</plays>`)
+		w.Write([]byte(body.String()))
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &queries
+}
+
+func TestQueryEach_Pages(t *testing.T) {
+	server, queries := pagedPlaysServer(t, "example user", 250)
+	client := gogeek.NewClient(gogeek.WithBaseURL(server.URL+"/xmlapi2"), gogeek.WithRateLimit(1000, 10))
+
+	var ids []int
+	plays, err := QueryEach(client, "example user", func(play Play) error {
+		ids = append(ids, play.ID)
+		return nil
+	})
+
+	require.NoError(t, err, "QueryEach should not return an error")
+	require.Len(t, ids, 250, "Every page of plays should be streamed")
+	require.Equal(t, 1, ids[0])
+	require.Equal(t, 250, ids[249])
+	require.Equal(t, 3, plays.Page, "Attributes should come from the last page read")
+	require.Equal(t, []string{
+		"username=example+user",
+		"page=2&username=example+user",
+		"page=3&username=example+user",
+	}, *queries, "Pages should be requested in order with the username escaped")
+}
+
+func TestQueryEach_StartPage(t *testing.T) {
+	server, queries := pagedPlaysServer(t, "example_user", 250)
+	client := gogeek.NewClient(gogeek.WithBaseURL(server.URL+"/xmlapi2"), gogeek.WithRateLimit(1000, 10))
+
+	count := 0
+	_, err := QueryEach(client, "example_user", func(play Play) error {
+		count++
+		return nil
+	}, WithPage(2))
+
+	require.NoError(t, err, "QueryEach should not return an error")
+	require.Equal(t, 150, count, "Plays before the start page should be skipped")
+	require.Len(t, *queries, 2)
+}
+
+func TestQuery_WithPage(t *testing.T) {
+	server, queries := pagedPlaysServer(t, "example_user", 250)
+	client := gogeek.NewClient(gogeek.WithBaseURL(server.URL + "/xmlapi2"))
+
+	plays, err := Query(client, "example_user", WithPage(3))
+
+	require.NoError(t, err, "Query should not return an error")
+	require.Equal(t, 3, plays.Page)
+	require.Len(t, plays.Plays, 50)
+	require.Equal(t, []string{"page=3&username=example_user"}, *queries)
+}
@@ -0,0 +1,91 @@
+package gogeek
+
+import (
+	"math"
+	"time"
+)
+
+// QueuePolicy controls how requests that BGG has queued for processing (HTTP 202) are polled.
+//
+// BGG answers some requests, most notably large collections, with 202 Accepted while it
+// prepares the response. The request is then re-sent after a delay that starts at InitialDelay
+// and grows by Multiplier on each poll, capped at MaxDelay, until the response is ready,
+// MaxPolls is reached or the total time spent queued would exceed Timeout.
+type QueuePolicy struct {
+	// MaxPolls is the maximum number of times a queued request is re-sent
+	// A value of 0 polls until Timeout is reached
+	MaxPolls int
+	// InitialDelay is the delay before the first poll
+	// A value of 0 uses the default of 2 seconds
+	InitialDelay time.Duration
+	// MaxDelay caps the delay between polls
+	// A value of 0 uses the default of 10 seconds, or InitialDelay if that is longer
+	MaxDelay time.Duration
+	// Multiplier grows the delay between polls (e.g., 1.5 increases each delay by 50%)
+	// Values below 1 are treated as 1
+	Multiplier float64
+	// Timeout is the maximum total time to spend waiting on a queued request, measured from
+	// the first 202 response and including the polls themselves. A value of 0 uses the
+	// default of 90 seconds, and a negative value disables the deadline
+	Timeout time.Duration
+}
+
+// DefaultQueuePolicy returns the queue policy used by new clients
+// It polls up to 10 times, starting at 2 seconds and backing off to at most 10 seconds,
+// for no longer than 90 seconds in total
+func DefaultQueuePolicy() QueuePolicy {
+	return QueuePolicy{
+		MaxPolls:     10,
+		InitialDelay: 2 * time.Second,
+		MaxDelay:     10 * time.Second,
+		Multiplier:   1.5,
+		Timeout:      90 * time.Second,
+	}
+}
+
+// Delay returns how long to wait before the given poll (0 for the first poll)
+func (p QueuePolicy) Delay(poll int) time.Duration {
+	defaults := DefaultQueuePolicy()
+	initialDelay, maxDelay := p.InitialDelay, p.MaxDelay
+	if initialDelay <= 0 {
+		initialDelay = defaults.InitialDelay
+	}
+	if maxDelay <= 0 {
+		maxDelay = max(defaults.MaxDelay, initialDelay)
+	}
+
+	multiplier := math.Max(p.Multiplier, 1)
+	delay := time.Duration(float64(initialDelay) * math.Pow(multiplier, float64(poll)))
+
+	if delay > maxDelay || delay < 0 {
+		delay = maxDelay
+	}
+
+	return delay
+}
+
+// Deadline returns the maximum total time to spend waiting on a queued request, applying
+// the default to a zero Timeout, or 0 if the policy has no deadline
+func (p QueuePolicy) Deadline() time.Duration {
+	switch {
+	case p.Timeout < 0:
+		return 0
+	case p.Timeout == 0:
+		return DefaultQueuePolicy().Timeout
+	default:
+		return p.Timeout
+	}
+}
+
+// QueuePolicy returns the queue polling policy for this client
+func (c *Client) QueuePolicy() QueuePolicy {
+	return c.queuePolicy
+}
+
+// WithQueuePolicy configures how the client polls requests that BGG has queued (HTTP 202)
+// Individual calls can override it with ContextWithQueuePolicy
+func WithQueuePolicy(policy QueuePolicy) ClientOption {
+	return func(c *Client) {
+		c.queuePolicy = policy
+	}
+}
@@ -0,0 +1,60 @@
+package gogeek
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueuePolicy_Delay(t *testing.T) {
+	policy := QueuePolicy{
+		InitialDelay: 2 * time.Second,
+		MaxDelay:     5 * time.Second,
+		Multiplier:   1.5,
+	}
+
+	require.Equal(t, 2*time.Second, policy.Delay(0), "First poll should use the initial delay")
+	require.Equal(t, 3*time.Second, policy.Delay(1), "Delay should grow by the multiplier")
+	require.Equal(t, 4500*time.Millisecond, policy.Delay(2), "Delay should keep growing")
+	require.Equal(t, 5*time.Second, policy.Delay(3), "Delay should be capped at MaxDelay")
+
+	policy.Multiplier = 0
+	require.Equal(t, 2*time.Second, policy.Delay(3), "Multipliers below 1 should keep a constant delay")
+}
+
+func TestWithQueuePolicy(t *testing.T) {
+	client := NewClient()
+	require.Equal(t, DefaultQueuePolicy(), client.QueuePolicy(), "New clients should use the default queue policy")
+
+	policy := QueuePolicy{MaxPolls: 20, InitialDelay: time.Second, Timeout: time.Minute}
+	client = NewClient(WithQueuePolicy(policy))
+	require.Equal(t, policy, client.QueuePolicy(), "Queue policy should be configurable")
+}
+
+func TestQueuePolicyFor(t *testing.T) {
+	client := NewClient()
+	require.Equal(t, client.QueuePolicy(), client.QueuePolicyFor(context.Background()), "Client policy should apply by default")
+
+	policy := QueuePolicy{InitialDelay: 5 * time.Second, Timeout: 2 * time.Minute}
+	ctx := ContextWithQueuePolicy(context.Background(), policy)
+	require.Equal(t, policy, client.QueuePolicyFor(ctx), "Per-call policy should override the client policy")
+}
+
+func TestQueuePolicy_Delay_Defaults(t *testing.T) {
+	defaults := DefaultQueuePolicy()
+
+	policy := QueuePolicy{Timeout: time.Minute}
+	require.Equal(t, defaults.InitialDelay, policy.Delay(0), "A zero initial delay should use the default")
+	require.Equal(t, defaults.MaxDelay, QueuePolicy{Multiplier: 10}.Delay(3), "A zero max delay should use the default")
+
+	policy = QueuePolicy{InitialDelay: 30 * time.Second}
+	require.Equal(t, 30*time.Second, policy.Delay(0), "A zero max delay should not cap a longer initial delay")
+}
+
+func TestQueuePolicy_Deadline(t *testing.T) {
+	require.Equal(t, DefaultQueuePolicy().Timeout, QueuePolicy{InitialDelay: 5 * time.Second}.Deadline(), "A zero timeout should use the default")
+	require.Equal(t, time.Minute, QueuePolicy{Timeout: time.Minute}.Deadline())
+	require.Zero(t, QueuePolicy{Timeout: -1}.Deadline(), "A negative timeout should disable the deadline")
+}
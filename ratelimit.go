@@ -0,0 +1,130 @@
+package gogeek
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// Limiter controls how often requests are sent to the BGG API.
+//
+// A single Limiter can be shared by several clients (e.g., one per API key and one anonymous)
+// so they draw from the same request budget. Implementations must be safe for concurrent use.
+type Limiter interface {
+	// Wait blocks until a request may be sent or ctx is done, in which case it returns ctx.Err()
+	Wait(ctx context.Context) error
+}
+
+// RateLimiter is a token bucket Limiter allowing a steady rate of requests with optional bursts
+type RateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	burst    float64
+	tokens   float64
+	last     time.Time
+}
+
+// defaultRequestsPerSecond is the request rate new clients are limited to
+const defaultRequestsPerSecond = 2
+
+// NewRateLimiter creates a Limiter allowing perSecond requests per second on average
+// and up to burst requests at once. A burst below 1 is treated as 1, which spaces
+// every request evenly. A rate that isn't positive, such as an unset configuration
+// value, falls back to the default of 2 requests per second rather than disabling
+// the limit.
+//
+// Example:
+//
+//	shared := gogeek.NewRateLimiter(2, 1)
+//	keyed := gogeek.NewClient(gogeek.WithAPIKey("your-api-key"), gogeek.WithRateLimiter(shared))
+//	anonymous := gogeek.NewClient(gogeek.WithRateLimiter(shared))
+func NewRateLimiter(perSecond float64, burst int) *RateLimiter {
+	if !(perSecond > 0) {
+		perSecond = defaultRequestsPerSecond
+	}
+	if burst < 1 {
+		burst = 1
+	}
+
+	return &RateLimiter{
+		interval: time.Duration(float64(time.Second) / perSecond),
+		burst:    float64(burst),
+		tokens:   float64(burst),
+		last:     time.Now(),
+	}
+}
+
+// Wait blocks until a request may be sent or ctx is done
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	now := time.Now()
+	l.tokens = math.Min(l.burst, l.tokens+float64(now.Sub(l.last))/float64(l.interval))
+	l.last = now
+	l.tokens--
+	delay := time.Duration(-l.tokens * float64(l.interval))
+	l.mu.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		// Give back the reserved slot so later callers aren't delayed by an abandoned request
+		l.mu.Lock()
+		l.tokens++
+		l.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+// Limiter returns the rate limiter shared by every request from this client
+func (c *Client) Limiter() Limiter {
+	return c.limiter
+}
+
+// EndpointLimiter returns the additional rate limiter for the named endpoint (e.g., "collection"), or nil if none is set
+func (c *Client) EndpointLimiter(endpoint string) Limiter {
+	return c.endpointLimiters[endpoint]
+}
+
+// WithRateLimiter configures the client to use the given Limiter
+// Passing the same Limiter to several clients makes them share one request budget
+func WithRateLimiter(limiter Limiter) ClientOption {
+	return func(c *Client) {
+		if limiter != nil {
+			c.limiter = limiter
+		}
+	}
+}
+
+// WithRateLimit configures the client to allow perSecond requests per second with bursts of up to burst requests
+func WithRateLimit(perSecond float64, burst int) ClientOption {
+	return func(c *Client) {
+		if perSecond > 0 {
+			c.limiter = NewRateLimiter(perSecond, burst)
+		}
+	}
+}
+
+// WithEndpointRateLimiter adds a Limiter for requests to the named endpoint (e.g., "collection")
+// Requests to the endpoint wait on both the client's limiter and the endpoint limiter,
+// so the endpoint limiter can only lower the rate for that endpoint
+func WithEndpointRateLimiter(endpoint string, limiter Limiter) ClientOption {
+	return func(c *Client) {
+		if c.endpointLimiters == nil {
+			c.endpointLimiters = make(map[string]Limiter)
+		}
+		c.endpointLimiters[endpoint] = limiter
+	}
+}
@@ -0,0 +1,85 @@
+package gogeek
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimiter_Burst(t *testing.T) {
+	limiter := NewRateLimiter(10, 3)
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		require.NoError(t, limiter.Wait(context.Background()))
+	}
+	require.Less(t, time.Since(start), 50*time.Millisecond, "Burst requests should not wait")
+
+	start = time.Now()
+	require.NoError(t, limiter.Wait(context.Background()))
+	duration := time.Since(start)
+	require.Greater(t, duration, 80*time.Millisecond, "Requests beyond the burst should wait for the rate")
+	require.Less(t, duration, 200*time.Millisecond, "Requests beyond the burst should not wait too long")
+}
+
+func TestNewRateLimiter_NonPositiveRate(t *testing.T) {
+	for _, rate := range []float64{0, -1} {
+		limiter := NewRateLimiter(rate, 1)
+		require.Equal(t, time.Second/defaultRequestsPerSecond, limiter.interval, "A non-positive rate should fall back to the default")
+
+		require.NoError(t, limiter.Wait(context.Background()))
+		start := time.Now()
+		require.NoError(t, limiter.Wait(context.Background()))
+		require.Greater(t, time.Since(start), 400*time.Millisecond, "Requests beyond the burst should still wait")
+	}
+}
+
+func TestRateLimiter_Cancelled(t *testing.T) {
+	limiter := NewRateLimiter(1, 1)
+	require.NoError(t, limiter.Wait(context.Background()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := limiter.Wait(ctx)
+	require.ErrorIs(t, err, context.DeadlineExceeded, "Wait should return the context error")
+	require.Less(t, time.Since(start), 200*time.Millisecond, "Wait should return once the context is done")
+
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	require.ErrorIs(t, limiter.Wait(ctx), context.Canceled, "Wait should not reserve a slot for a cancelled context")
+}
+
+func TestWithRateLimiter_Shared(t *testing.T) {
+	shared := NewRateLimiter(5, 1)
+	first := NewClient(WithAPIKey("key"), WithRateLimiter(shared))
+	second := NewClient(WithRateLimiter(shared))
+
+	require.Same(t, shared, first.Limiter(), "Client should use the shared limiter")
+
+	require.NoError(t, first.Limiter().Wait(context.Background()))
+	start := time.Now()
+	require.NoError(t, second.Limiter().Wait(context.Background()))
+	require.Greater(t, time.Since(start), 150*time.Millisecond, "Clients sharing a limiter should share its budget")
+}
+
+func TestWithRateLimit(t *testing.T) {
+	client := NewClient(WithRateLimit(100, 5))
+
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		require.NoError(t, client.Limiter().Wait(context.Background()))
+	}
+	require.Less(t, time.Since(start), 50*time.Millisecond, "Configured burst should be allowed")
+}
+
+func TestWithEndpointRateLimiter(t *testing.T) {
+	collectionLimiter := NewRateLimiter(0.5, 1)
+	client := NewClient(WithEndpointRateLimiter("collection", collectionLimiter))
+
+	require.Same(t, collectionLimiter, client.EndpointLimiter("collection"), "Endpoint limiter should be configured")
+	require.Nil(t, client.EndpointLimiter("thing"), "Other endpoints should not have a limiter")
+}
@@ -0,0 +1,73 @@
+package request
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/url"
+	"path"
+
+	"github.com/kkjdaniel/gogeek/v2"
+)
+
+// endpointName returns the name of the API endpoint a URL targets (e.g. "thing"),
+// which is the last segment of its path.
+func endpointName(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return path.Base(u.Path)
+}
+
+// requestKey identifies a request by its canonical URL, with query parameters
+// sorted, and the authentication identity of the client making it. Credentials
+// are hashed so they are never stored in plain text.
+func requestKey(client *gogeek.Client, rawURL string) string {
+	canonical := rawURL
+	if u, err := url.Parse(rawURL); err == nil {
+		u.RawQuery = u.Query().Encode()
+		canonical = u.String()
+	}
+
+	return authIdentity(client) + " " + canonical
+}
+
+// authIdentity returns a stable, non-reversible identifier for the client's credentials
+func authIdentity(client *gogeek.Client) string {
+	var secret string
+	switch client.AuthMode() {
+	case gogeek.AuthAPIKey:
+		secret = "apikey:" + client.APIKey()
+	case gogeek.AuthCookie:
+		secret = "cookie:" + client.CookieString()
+	default:
+		return "anonymous"
+	}
+
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:8])
+}
+
+// cachedBody returns the cached response body for the call, if the client has a
+// cache, the endpoint is cacheable and the context doesn't bypass the cache.
+func cachedBody(ctx context.Context, client *gogeek.Client, c *call) ([]byte, bool) {
+	cache := client.Cache()
+	if cache == nil || client.CacheTTL(endpointName(c.url)) <= 0 || gogeek.CacheBypassed(ctx) {
+		return nil, false
+	}
+
+	return cache.Get(requestKey(client, c.url))
+}
+
+// storeBody caches a successfully decoded response body for the call
+func storeBody(client *gogeek.Client, c *call, body []byte) {
+	cache := client.Cache()
+	if cache == nil {
+		return
+	}
+
+	if ttl := client.CacheTTL(endpointName(c.url)); ttl > 0 {
+		cache.Set(requestKey(client, c.url), body, ttl)
+	}
+}
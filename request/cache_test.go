@@ -0,0 +1,29 @@
+package request
+
+import (
+	"testing"
+
+	"github.com/kkjdaniel/gogeek/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEndpointName(t *testing.T) {
+	require.Equal(t, "thing", endpointName("https://boardgamegeek.com/xmlapi2/thing?id=13&stats=1"))
+	require.Equal(t, "collection", endpointName("http://localhost:8080/mirror/xmlapi2/collection?username=test"))
+}
+
+func TestRequestKey(t *testing.T) {
+	anonymous := gogeek.NewClient()
+	withKey := gogeek.NewClient(gogeek.WithAPIKey("secret-key"))
+
+	require.Equal(t,
+		requestKey(anonymous, "https://boardgamegeek.com/xmlapi2/thing?id=13&stats=1"),
+		requestKey(anonymous, "https://boardgamegeek.com/xmlapi2/thing?stats=1&id=13"),
+		"Keys should not depend on query parameter order")
+	require.NotEqual(t,
+		requestKey(anonymous, "https://boardgamegeek.com/xmlapi2/thing?id=13"),
+		requestKey(withKey, "https://boardgamegeek.com/xmlapi2/thing?id=13"),
+		"Keys should depend on the client's credentials")
+	require.NotContains(t, requestKey(withKey, "https://boardgamegeek.com/xmlapi2/thing?id=13"), "secret-key",
+		"Keys should not contain credentials")
+}
@@ -0,0 +1,128 @@
+package request
+
+import (
+	"bufio"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/kkjdaniel/gogeek/v2"
+)
+
+// acceptEncoding lists the content encodings requested when compression is enabled
+const acceptEncoding = "gzip, deflate"
+
+// setHeaders adds the client's User-Agent, extra headers, compression preference and
+// authentication to req
+func setHeaders(req *http.Request, client *gogeek.Client) {
+	req.Header.Set("User-Agent", client.UserAgent())
+
+	if client.Compression() {
+		req.Header.Set("Accept-Encoding", acceptEncoding)
+	}
+
+	for key, values := range client.Headers() {
+		req.Header[key] = values
+	}
+
+	// Add authentication headers based on client configuration
+	switch client.AuthMode() {
+	case gogeek.AuthAPIKey:
+		req.Header.Set("Authorization", "Bearer "+client.APIKey())
+	case gogeek.AuthCookie:
+		req.Header.Set("Cookie", client.CookieString())
+	}
+}
+
+// decompress replaces a gzip or deflate encoded response body with a reader that
+// decompresses it, so the rest of the request layer sees the original XML. Responses
+// with any other encoding are returned unchanged.
+//
+// The decompressor is only created once the body is first read, so an empty body, as BGG
+// sends with some 202 and 5xx responses, reads as empty rather than failing the request.
+func decompress(resp *http.Response) {
+	var open func(io.Reader) (io.ReadCloser, error)
+
+	switch strings.ToLower(strings.TrimSpace(resp.Header.Get("Content-Encoding"))) {
+	case "gzip", "x-gzip":
+		open = func(r io.Reader) (io.ReadCloser, error) { return gzip.NewReader(r) }
+	case "deflate":
+		open = newDeflateReader
+	default:
+		return
+	}
+
+	resp.Body = &decompressedBody{open: open, compressed: resp.Body}
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = -1
+	resp.Uncompressed = true
+}
+
+// newDeflateReader returns a reader for a deflate encoded body. HTTP's deflate encoding is
+// zlib wrapped, but some servers send a raw deflate stream, so both are accepted.
+func newDeflateReader(r io.Reader) (io.ReadCloser, error) {
+	br := bufio.NewReader(r)
+
+	header, err := br.Peek(2)
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+
+	// A zlib header is a compression method of 8 (deflate) with a checksum making it a multiple of 31
+	if len(header) == 2 && header[0]&0x0f == 8 && (uint16(header[0])<<8|uint16(header[1]))%31 == 0 {
+		return zlib.NewReader(br)
+	}
+
+	return flate.NewReader(br), nil
+}
+
+// decompressedBody lazily decompresses the underlying response body, and closes both
+// the decompressing reader and the response body
+type decompressedBody struct {
+	open       func(io.Reader) (io.ReadCloser, error)
+	compressed io.ReadCloser
+	reader     io.ReadCloser
+	err        error
+}
+
+func (b *decompressedBody) Read(p []byte) (int, error) {
+	if b.reader == nil && b.err == nil {
+		reader, err := b.open(b.compressed)
+		switch {
+		case err == io.EOF:
+			b.err = err
+		case err != nil:
+			b.err = &decompressError{err: err}
+		default:
+			b.reader = reader
+		}
+	}
+	if b.err != nil {
+		return 0, b.err
+	}
+
+	n, err := b.reader.Read(p)
+	if err != nil && err != io.EOF {
+		err = &decompressError{err: err}
+	}
+	return n, err
+}
+
+func (b *decompressedBody) Close() error {
+	if b.reader != nil {
+		b.reader.Close()
+	}
+	return b.compressed.Close()
+}
+
+// decompressError reports a compressed body that could not be decoded
+type decompressError struct {
+	err error
+}
+
+func (e *decompressError) Error() string { return "decompressing response: " + e.err.Error() }
+func (e *decompressError) Unwrap() error { return e.err }
@@ -0,0 +1,171 @@
+package request
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/kkjdaniel/gogeek/v2"
+	"github.com/stretchr/testify/require"
+)
+
+const encodedXML = `<item id="123"><title>Compressed &amp; decoded</title></item>`
+
+func compress(t *testing.T, newWriter func(io.Writer) io.WriteCloser) []byte {
+	var buf bytes.Buffer
+	w := newWriter(&buf)
+	_, err := w.Write([]byte(encodedXML))
+	require.NoError(t, err, "Compression should succeed")
+	require.NoError(t, w.Close(), "Compression should succeed")
+	return buf.Bytes()
+}
+
+func TestFetchAndUnmarshal_Decompression(t *testing.T) {
+	tests := []struct {
+		name     string
+		encoding string
+		body     []byte
+	}{
+		{name: "Identity", encoding: "", body: []byte(encodedXML)},
+		{name: "Gzip", encoding: "gzip", body: compress(t, func(w io.Writer) io.WriteCloser { return gzip.NewWriter(w) })},
+		{name: "Zlib deflate", encoding: "deflate", body: compress(t, func(w io.Writer) io.WriteCloser { return zlib.NewWriter(w) })},
+		{name: "Raw deflate", encoding: "Deflate", body: compress(t, func(w io.Writer) io.WriteCloser {
+			fw, _ := flate.NewWriter(w, flate.DefaultCompression)
+			return fw
+		})},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testURL := "https://example.com/xmlapi2/thing?id=123"
+			transport := httpmock.NewMockTransport()
+			transport.RegisterResponder("GET", testURL, func(req *http.Request) (*http.Response, error) {
+				resp := httpmock.NewBytesResponse(http.StatusOK, tt.body)
+				if tt.encoding != "" {
+					resp.Header.Set("Content-Encoding", tt.encoding)
+				}
+				return resp, nil
+			})
+
+			var result struct {
+				Title string `xml:"title"`
+			}
+			var info gogeek.ResponseInfo
+			client := gogeek.NewClient(gogeek.WithTransport(transport))
+			err := FetchAndUnmarshalContext(gogeek.ContextWithResponseInfo(context.Background(), &info), client, testURL, &result)
+
+			require.NoError(t, err, "FetchAndUnmarshal should succeed")
+			require.Equal(t, "Compressed & decoded", result.Title, "Response should be decompressed and decoded")
+			require.Equal(t, encodedXML, string(info.RawBody), "Raw body should be decompressed")
+			require.Empty(t, info.Header.Get("Content-Encoding"), "Content-Encoding should be removed once decoded")
+		})
+	}
+}
+
+func TestFetchAndUnmarshal_CorruptCompressedBody(t *testing.T) {
+	testURL := "https://example.com/xmlapi2/thing?id=123"
+	transport := httpmock.NewMockTransport()
+	transport.RegisterResponder("GET", testURL, func(req *http.Request) (*http.Response, error) {
+		resp := httpmock.NewStringResponse(http.StatusOK, encodedXML)
+		resp.Header.Set("Content-Encoding", "gzip")
+		return resp, nil
+	})
+
+	var result struct{}
+	err := FetchAndUnmarshal(gogeek.NewClient(gogeek.WithTransport(transport)), testURL, &result)
+
+	require.ErrorIs(t, err, ErrHTTPError, "Corrupt compressed bodies should fail")
+}
+
+func TestFetchAndUnmarshal_EmptyCompressedBodyRetried(t *testing.T) {
+	testURL := "https://example.com/xmlapi2/thing?id=123"
+	statuses := []int{http.StatusServiceUnavailable, http.StatusAccepted, http.StatusOK}
+	calls := 0
+	compressed := compress(t, func(w io.Writer) io.WriteCloser { return gzip.NewWriter(w) })
+
+	transport := httpmock.NewMockTransport()
+	transport.RegisterResponder("GET", testURL, func(req *http.Request) (*http.Response, error) {
+		status := statuses[calls]
+		calls++
+
+		var body []byte
+		if status == http.StatusOK {
+			body = compressed
+		}
+		resp := httpmock.NewBytesResponse(status, body)
+		resp.Header.Set("Content-Encoding", "gzip")
+		return resp, nil
+	})
+
+	var result struct {
+		Title string `xml:"title"`
+	}
+	client := gogeek.NewClient(
+		gogeek.WithTransport(transport),
+		gogeek.WithRateLimit(1000, 10),
+		gogeek.WithRetryPolicy(gogeek.RetryPolicy{MaxAttempts: 2, RetryableStatuses: []int{http.StatusServiceUnavailable}}),
+		gogeek.WithQueuePolicy(gogeek.QueuePolicy{MaxPolls: 1, InitialDelay: time.Millisecond}),
+	)
+	err := FetchAndUnmarshal(client, testURL, &result)
+
+	require.NoError(t, err, "Empty compressed bodies on 503 and 202 responses should be retried and polled")
+	require.Equal(t, 3, calls)
+	require.Equal(t, "Compressed & decoded", result.Title)
+}
+
+func TestFetchAndUnmarshal_Headers(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     []gogeek.ClientOption
+		expected http.Header
+	}{
+		{
+			name: "Defaults",
+			expected: http.Header{
+				"User-Agent":      {gogeek.DefaultUserAgent},
+				"Accept-Encoding": {"gzip, deflate"},
+			},
+		},
+		{
+			name: "Custom",
+			opts: []gogeek.ClientOption{
+				gogeek.WithUserAgent("my-app/1.0"),
+				gogeek.WithHeader("X-Request-Source", "tests"),
+				gogeek.WithHeader("Authorization", "ignored"),
+				gogeek.WithAPIKey("key"),
+				gogeek.WithCompression(false),
+			},
+			expected: http.Header{
+				"User-Agent":       {"my-app/1.0"},
+				"X-Request-Source": {"tests"},
+				"Authorization":    {"Bearer key"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testURL := "https://example.com/xmlapi2/thing?id=123"
+			var sent http.Header
+			transport := httpmock.NewMockTransport()
+			transport.RegisterResponder("GET", testURL, func(req *http.Request) (*http.Response, error) {
+				sent = req.Header
+				return httpmock.NewStringResponse(http.StatusOK, encodedXML), nil
+			})
+
+			var result struct{}
+			client := gogeek.NewClient(append(tt.opts, gogeek.WithTransport(transport))...)
+			err := FetchAndUnmarshal(client, testURL, &result)
+
+			require.NoError(t, err, "FetchAndUnmarshal should succeed")
+			require.Equal(t, tt.expected, sent, "Request headers should match the client configuration")
+		})
+	}
+}
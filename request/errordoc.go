@@ -0,0 +1,82 @@
+package request
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+)
+
+// errorDocument models the in-band error documents BGG returns, often with a 200 status.
+// The known forms are:
+//
+//	<errors><error><message>Invalid username specified</message></error></errors>
+//	<error message="Rate limit exceeded."/>
+//	<error><message>Not found</message></error>
+type errorDocument struct {
+	XMLName       xml.Name
+	Attribute     string   `xml:"message,attr"`
+	Message       string   `xml:"message"`
+	NestedMessage []string `xml:"error>message"`
+	Text          string   `xml:",chardata"`
+}
+
+// parseErrorDocument returns the message of an in-band BGG error document.
+// The boolean result is false if the body's root element is not <error> or <errors>.
+func parseErrorDocument(body []byte) (string, bool) {
+	decoder := xml.NewDecoder(bytes.NewReader(body))
+	decoder.Strict = false
+
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return "", false
+		}
+
+		start, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+
+		if start.Name.Local != "error" && start.Name.Local != "errors" {
+			return "", false
+		}
+
+		var doc errorDocument
+		if err := decoder.DecodeElement(&doc, &start); err != nil {
+			return "", true
+		}
+
+		return doc.message(), true
+	}
+}
+
+func (d errorDocument) message() string {
+	var messages []string
+	for _, m := range append([]string{d.Attribute, d.Message}, d.NestedMessage...) {
+		if m = strings.TrimSpace(m); m != "" {
+			messages = append(messages, m)
+		}
+	}
+
+	if len(messages) == 0 {
+		return strings.TrimSpace(d.Text)
+	}
+
+	return strings.Join(messages, "; ")
+}
+
+// classifyErrorMessage maps a BGG error message to one of the package's sentinel errors
+func classifyErrorMessage(message string) error {
+	lower := strings.ToLower(message)
+
+	switch {
+	case strings.Contains(lower, "username"), strings.Contains(lower, "user not found"):
+		return ErrUserNotFound
+	case strings.Contains(lower, "not found"):
+		return ErrItemNotFound
+	case strings.Contains(lower, "invalid"):
+		return ErrInvalidParameter
+	default:
+		return ErrBGGError
+	}
+}
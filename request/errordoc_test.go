@@ -0,0 +1,67 @@
+package request
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseErrorDocument(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		message string
+		isError bool
+	}{
+		{
+			name:    "Errors document",
+			input:   `<?xml version="1.0" encoding="utf-8" standalone="yes"?><errors><error><message>Invalid username specified</message></error></errors>`,
+			message: "Invalid username specified",
+			isError: true,
+		},
+		{
+			name:    "Error with message attribute",
+			input:   `<error message="Rate limit exceeded."/>`,
+			message: "Rate limit exceeded.",
+			isError: true,
+		},
+		{
+			name:    "Error with message element",
+			input:   `<error><message>Item not found</message></error>`,
+			message: "Item not found",
+			isError: true,
+		},
+		{
+			name:    "Error with text",
+			input:   `<error>Guild not found.</error>`,
+			message: "Guild not found.",
+			isError: true,
+		},
+		{
+			name:    "Regular document",
+			input:   `<items total="0" termsofuse="https://boardgamegeek.com/xmlapi/termsofuse"></items>`,
+			isError: false,
+		},
+		{
+			name:    "Not XML",
+			input:   `This is not valid XML`,
+			isError: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			message, ok := parseErrorDocument([]byte(tt.input))
+			require.Equal(t, tt.isError, ok, "Error document detection should match")
+			require.Equal(t, tt.message, message, "Error message should match")
+		})
+	}
+}
+
+func TestClassifyErrorMessage(t *testing.T) {
+	require.Equal(t, ErrUserNotFound, classifyErrorMessage("Invalid username specified"))
+	require.Equal(t, ErrUserNotFound, classifyErrorMessage("User not found"))
+	require.Equal(t, ErrItemNotFound, classifyErrorMessage("Item not found"))
+	require.Equal(t, ErrInvalidParameter, classifyErrorMessage("Invalid object type"))
+	require.Equal(t, ErrBGGError, classifyErrorMessage("Something went wrong"))
+}
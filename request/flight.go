@@ -0,0 +1,104 @@
+package request
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/kkjdaniel/gogeek/v2"
+)
+
+// flights coalesces concurrent identical requests so they share one HTTP request
+var flights = &flightGroup{flights: make(map[string]*flight)}
+
+// flightGroup tracks the requests currently in flight, keyed by client, credentials and URL
+type flightGroup struct {
+	mu      sync.Mutex
+	flights map[string]*flight
+}
+
+// flight is a single in-flight request shared by one or more callers
+type flight struct {
+	done    chan struct{}
+	body    []byte
+	stats   call
+	err     error
+	waiters int
+	cancel  context.CancelFunc
+}
+
+// flightKey identifies requests that can share a flight: the same URL requested with the
+// same credentials and queue policy through the same client. Calls overriding the queue
+// policy with gogeek.ContextWithQueuePolicy only share flights with calls using the same policy.
+func flightKey(ctx context.Context, client *gogeek.Client, rawURL string) string {
+	return fmt.Sprintf("%p %+v %s", client, client.QueuePolicyFor(ctx), requestKey(client, rawURL))
+}
+
+// do runs fetch for key, or waits for an identical fetch already in flight. The boolean
+// result reports whether the response was shared with another caller.
+//
+// The shared fetch runs with a context that keeps the first caller's values but is
+// only cancelled once every waiting caller's context is done, so one caller giving up
+// doesn't fail the others. Because of that, middlewares and the client's observer see
+// the shared HTTP attempts in the first caller's context; the other callers' own calls
+// are still observed, reporting the response as shared. Each caller returns as soon as its own context is done,
+// except the last, which waits for the cancelled request to unwind.
+func (g *flightGroup) do(ctx context.Context, key string, fetch func(context.Context, *call) ([]byte, error)) ([]byte, call, bool, error) {
+	// A caller that has already given up must not start a request that outlives it
+	if err := ctx.Err(); err != nil {
+		return nil, call{}, false, err
+	}
+
+	g.mu.Lock()
+	f, shared := g.flights[key]
+	if shared {
+		f.waiters++
+	} else {
+		flightCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		f = &flight{done: make(chan struct{}), waiters: 1, cancel: cancel}
+		g.flights[key] = f
+		go g.run(flightCtx, key, f, fetch)
+	}
+	g.mu.Unlock()
+
+	select {
+	case <-f.done:
+		return f.body, f.stats, shared, f.err
+	case <-ctx.Done():
+		g.mu.Lock()
+		f.waiters--
+		last := f.waiters == 0
+		if last {
+			g.forget(key, f)
+		}
+		g.mu.Unlock()
+
+		if last {
+			// Abandon the request and wait for it to unwind, so nothing is left
+			// running once the last caller returns
+			f.cancel()
+			<-f.done
+		}
+		return nil, call{}, shared, ctx.Err()
+	}
+}
+
+func (g *flightGroup) run(ctx context.Context, key string, f *flight, fetch func(context.Context, *call) ([]byte, error)) {
+	defer f.cancel()
+
+	f.body, f.err = fetch(ctx, &f.stats)
+
+	g.mu.Lock()
+	g.forget(key, f)
+	g.mu.Unlock()
+
+	close(f.done)
+}
+
+// forget removes f from the group so later callers start a new flight. It must be
+// called with g.mu held.
+func (g *flightGroup) forget(key string, f *flight) {
+	if g.flights[key] == f {
+		delete(g.flights, key)
+	}
+}
@@ -0,0 +1,203 @@
+package request
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/kkjdaniel/gogeek/v2"
+	"github.com/kkjdaniel/gogeek/v2/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+// blockingTransport returns a mock transport whose responses are held until release is closed
+func blockingTransport(t *testing.T, url string, release <-chan struct{}) *httpmock.MockTransport {
+	mockData := testutils.LoadTestData(t, `testdata/valid.xml`)
+
+	transport := httpmock.NewMockTransport()
+	transport.RegisterResponder("GET", url, func(req *http.Request) (*http.Response, error) {
+		select {
+		case <-release:
+			return httpmock.NewBytesResponse(http.StatusOK, mockData), nil
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+	})
+
+	return transport
+}
+
+// waitForWaiters blocks until the flight for key has the given number of waiters
+func waitForWaiters(t *testing.T, key string, waiters int) {
+	require.Eventually(t, func() bool {
+		flights.mu.Lock()
+		defer flights.mu.Unlock()
+		f, ok := flights.flights[key]
+		return ok && f.waiters == waiters
+	}, time.Second, time.Millisecond, "Callers should join the same flight")
+}
+
+func TestFetchAndUnmarshal_CoalescesConcurrentRequests(t *testing.T) {
+	type TestXML struct {
+		ID    int    `xml:"id,attr"`
+		Title string `xml:"title"`
+	}
+
+	testURL := "https://example.com/xmlapi2/thing?id=123"
+	release := make(chan struct{})
+	transport := blockingTransport(t, testURL, release)
+	client := gogeek.NewClient(gogeek.WithTransport(transport))
+
+	const callers = 5
+	results := make([]*TestXML, callers)
+	infos := make([]gogeek.ResponseInfo, callers)
+	errs := make([]error, callers)
+
+	var wg sync.WaitGroup
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i] = &TestXML{}
+			ctx := gogeek.ContextWithResponseInfo(context.Background(), &infos[i])
+			errs[i] = FetchAndUnmarshalContext(ctx, client, testURL, results[i])
+		}(i)
+	}
+
+	waitForWaiters(t, flightKey(context.Background(), client, testURL), callers)
+	close(release)
+	wg.Wait()
+
+	shared := 0
+	for i := 0; i < callers; i++ {
+		require.NoError(t, errs[i], "Every caller should succeed")
+		require.Equal(t, 123, results[i].ID, "Every caller should decode the response")
+		if infos[i].Shared {
+			shared++
+		}
+	}
+
+	require.Equal(t, 1, transport.GetTotalCallCount(), "Concurrent identical requests should share one HTTP request")
+	require.Equal(t, callers-1, shared, "Every caller but the first should report a shared response")
+}
+
+func TestFetchAndUnmarshal_CoalescedCallerCancellation(t *testing.T) {
+	testURL := "https://example.com/xmlapi2/thing?id=456"
+	release := make(chan struct{})
+	transport := blockingTransport(t, testURL, release)
+	client := gogeek.NewClient(gogeek.WithTransport(transport))
+	key := flightKey(context.Background(), client, testURL)
+
+	firstCtx, cancelFirst := context.WithCancel(context.Background())
+	firstErr := make(chan error, 1)
+	go func() {
+		var result struct{}
+		firstErr <- FetchAndUnmarshalContext(firstCtx, client, testURL, &result)
+	}()
+	waitForWaiters(t, key, 1)
+
+	secondErr := make(chan error, 1)
+	go func() {
+		var result struct{}
+		secondErr <- FetchAndUnmarshalContext(context.Background(), client, testURL, &result)
+	}()
+	waitForWaiters(t, key, 2)
+
+	cancelFirst()
+	require.ErrorIs(t, <-firstErr, context.Canceled, "Cancelled caller should return its context error")
+
+	close(release)
+	require.NoError(t, <-secondErr, "Remaining caller should still receive the shared response")
+}
+
+func TestFetchAndUnmarshal_CoalescedRequestCancelledWithLastCaller(t *testing.T) {
+	testURL := "https://example.com/xmlapi2/thing?id=789"
+	transport := blockingTransport(t, testURL, make(chan struct{}))
+	client := gogeek.NewClient(gogeek.WithTransport(transport))
+	key := flightKey(context.Background(), client, testURL)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error, 1)
+	go func() {
+		var result struct{}
+		errCh <- FetchAndUnmarshalContext(ctx, client, testURL, &result)
+	}()
+	waitForWaiters(t, key, 1)
+
+	cancel()
+	require.ErrorIs(t, <-errCh, context.Canceled, "Caller should return its context error")
+
+	require.Eventually(t, func() bool {
+		flights.mu.Lock()
+		defer flights.mu.Unlock()
+		_, ok := flights.flights[key]
+		return !ok
+	}, time.Second, time.Millisecond, "Request should be abandoned once every caller has gone")
+}
+
+func TestFetchAndUnmarshal_QueuePolicyOverrideNotCoalesced(t *testing.T) {
+	testURL := "https://example.com/xmlapi2/thing?id=321"
+	release := make(chan struct{})
+	transport := blockingTransport(t, testURL, release)
+	client := gogeek.NewClient(gogeek.WithTransport(transport), gogeek.WithRateLimit(1000, 10))
+
+	override := gogeek.ContextWithQueuePolicy(context.Background(), gogeek.QueuePolicy{MaxPolls: 1, Timeout: time.Minute})
+	contexts := []context.Context{context.Background(), context.Background(), override, override}
+	require.NotEqual(t, flightKey(context.Background(), client, testURL), flightKey(override, client, testURL),
+		"A per-call queue policy should be part of the flight key")
+
+	errs := make([]error, len(contexts))
+	var wg sync.WaitGroup
+	for i, ctx := range contexts {
+		wg.Add(1)
+		go func(i int, ctx context.Context) {
+			defer wg.Done()
+			var result struct{}
+			errs[i] = FetchAndUnmarshalContext(ctx, client, testURL, &result)
+		}(i, ctx)
+	}
+
+	waitForWaiters(t, flightKey(context.Background(), client, testURL), 2)
+	waitForWaiters(t, flightKey(override, client, testURL), 2)
+	close(release)
+	wg.Wait()
+
+	for _, err := range errs {
+		require.NoError(t, err, "Every caller should succeed")
+	}
+	require.Equal(t, 2, transport.GetTotalCallCount(), "Calls should only share a request with calls using the same queue policy")
+}
+
+type countingLimiter struct {
+	waits atomic.Int32
+}
+
+func (l *countingLimiter) Wait(ctx context.Context) error {
+	l.waits.Add(1)
+	return ctx.Err()
+}
+
+func TestFetchAndUnmarshal_CancelledCallerStartsNoFlight(t *testing.T) {
+	testURL := "https://example.com/xmlapi2/thing?id=654"
+	transport := httpmock.NewMockTransport()
+	limiter := &countingLimiter{}
+	client := gogeek.NewClient(gogeek.WithTransport(transport), gogeek.WithRateLimiter(limiter))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	for i := 0; i < 10; i++ {
+		var result struct{}
+		err := FetchAndUnmarshalContext(ctx, client, testURL, &result)
+		require.ErrorIs(t, err, context.Canceled, "A cancelled call should return the context error")
+	}
+
+	// Any flight would have been started in the background, so give it time to reach the limiter
+	time.Sleep(20 * time.Millisecond)
+	require.Zero(t, limiter.waits.Load(), "Cancelled calls should not wait on the rate limiter")
+	require.Zero(t, transport.GetTotalCallCount(), "Cancelled calls should not send requests")
+}
@@ -0,0 +1,46 @@
+package request
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/kkjdaniel/gogeek/v2"
+)
+
+// log emits a structured event for the call to the client's logger, if it has one.
+// The endpoint, redacted URL and attempt count are always included.
+func (c *call) log(ctx context.Context, client *gogeek.Client, level slog.Level, msg string, attrs ...slog.Attr) {
+	logger := client.Logger()
+	if logger == nil || !logger.Enabled(ctx, level) {
+		return
+	}
+
+	attrs = append([]slog.Attr{
+		slog.String("endpoint", endpointName(c.url)),
+		slog.String("url", gogeek.RedactURL(c.url)),
+		slog.Int("attempt", c.attempts),
+	}, attrs...)
+
+	logger.LogAttrs(ctx, level, msg, attrs...)
+}
+
+// logResult emits the outcome of a call: a debug event on success, a warning on failure
+func (c *call) logResult(ctx context.Context, client *gogeek.Client, start time.Time, err error) {
+	attrs := []slog.Attr{
+		slog.Int("status", c.statusCode),
+		slog.Duration("duration", time.Since(start)),
+		slog.Duration("queued_for", c.queuedFor),
+		slog.Bool("cache_hit", c.cacheHit),
+		slog.Bool("shared", c.shared),
+		slog.Bool("sanitised", c.sanitised),
+		slog.Bool("fallback_parser", c.fallback),
+	}
+
+	if err != nil {
+		c.log(ctx, client, slog.LevelWarn, "BGG request failed", append(attrs, slog.Any("error", err))...)
+		return
+	}
+
+	c.log(ctx, client, slog.LevelDebug, "BGG request completed", attrs...)
+}
@@ -0,0 +1,41 @@
+package request
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/kkjdaniel/gogeek/v2"
+)
+
+// observe notifies the client's observer, if it has one, that the call is starting.
+// It returns the context to use for the rest of the call and a function to call
+// with the outcome, which does nothing if the client has no observer.
+func (c *call) observe(ctx context.Context, client *gogeek.Client) (context.Context, func(gogeek.ResponseInfo, error)) {
+	observer := client.Observer()
+	if observer == nil {
+		return ctx, func(gogeek.ResponseInfo, error) {}
+	}
+
+	return observer.StartCall(ctx, gogeek.CallInfo{
+		Endpoint:  endpointName(c.url),
+		URL:       gogeek.RedactURL(c.url),
+		ItemCount: itemCount(c.url),
+	})
+}
+
+// itemCount returns the number of comma-separated IDs in the URL's id parameter
+func itemCount(rawURL string) int {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return 0
+	}
+
+	count := 0
+	for _, id := range strings.Split(u.Query().Get("id"), ",") {
+		if strings.TrimSpace(id) != "" {
+			count++
+		}
+	}
+	return count
+}
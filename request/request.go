@@ -1,12 +1,15 @@
 package request
 
 import (
+	"bytes"
+	"context"
 	"encoding/xml"
+	"errors"
 	"fmt"
-	"html"
 	"io"
+	"log/slog"
 	"net/http"
-	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
@@ -14,10 +17,8 @@ import (
 	"github.com/kkjdaniel/gogeek/v2"
 )
 
-var (
-	maxRetries = 5
-	retryDelay = 3 * time.Second
-)
+// maxErrorBodyLength is the maximum number of response body bytes included in an APIError
+const maxErrorBodyLength = 512
 
 var (
 	// ErrEmptyResponse is returned when the response body is empty
@@ -28,120 +29,390 @@ var (
 	ErrUnexpectedStatusCode = fmt.Errorf("unexpected status code")
 	// ErrMaxRetriesExceeded is returned when the maximum number of retries is exceeded
 	ErrMaxRetriesExceeded = fmt.Errorf("exceeded maximum retries while waiting for BGG to process request")
+	// ErrQueueTimeout is returned alongside ErrMaxRetriesExceeded when a queued request exceeds the QueuePolicy timeout
+	ErrQueueTimeout = fmt.Errorf("timed out waiting for BGG to process queued request")
 	// ErrUnmarshalError is returned when the XML response cannot be unmarshalled
 	ErrUnmarshalError = fmt.Errorf("failed to unmarshal XML response")
 	// ErrRegenerateError is returned when the XML response cannot be regenerated
 	ErrRegenerateError = fmt.Errorf("failed to regenerate XML response")
 	// ErrXMLParseError is returned when the XML response cannot be parsed
 	ErrXMLParseError = fmt.Errorf("failed to parse XML response")
+	// ErrBGGError is returned when BGG responds with an <error> document that doesn't match a more specific error
+	ErrBGGError = fmt.Errorf("BGG returned an error")
+	// ErrUserNotFound is returned when BGG reports that the requested username is invalid
+	ErrUserNotFound = fmt.Errorf("user not found")
+	// ErrItemNotFound is returned when BGG reports that the requested item does not exist
+	ErrItemNotFound = fmt.Errorf("item not found")
+	// ErrInvalidParameter is returned when BGG reports that a request parameter is invalid
+	ErrInvalidParameter = fmt.Errorf("invalid parameter")
 )
 
+// FetchAndUnmarshal performs a GET request against the BGG API and unmarshals
+// the XML response into v. It is equivalent to calling FetchAndUnmarshalContext
+// with context.Background().
 func FetchAndUnmarshal(client *gogeek.Client, url string, v interface{}) error {
-	for attempt := 0; attempt <= maxRetries; attempt++ {
-		client.Limiter().Take()
+	return FetchAndUnmarshalContext(context.Background(), client, url, v)
+}
+
+// FetchAndUnmarshalContext performs a GET request against the BGG API and
+// unmarshals the XML response into v.
+//
+// The context is honoured while waiting on the client's rate limiter, during
+// the HTTP round trip and while backing off between retries. If the context is
+// cancelled or its deadline passes, the context's error is returned.
+//
+// Queued (202) responses are polled according to the client's QueuePolicy, which
+// can be overridden for a single call with gogeek.ContextWithQueuePolicy.
+// Responses with a status listed in the client's RetryPolicy (by default 429 and
+// transient 5xx errors) are retried with exponential backoff, honouring any
+// Retry-After header. If the context carries a gogeek.ResponseInfo it is filled
+// in with the outcome of the request, and if the client has a logger, structured
+// events are emitted for polls, retries, XML repairs and the final outcome. If the
+// client has a gogeek.Observer, it is notified when the call starts and ends.
+//
+// If the client has a gogeek.Cache, responses are served from and stored in it
+// according to the endpoint's TTL, unless the context was created with
+// gogeek.ContextWithoutCache.
+//
+// Concurrent calls for the same URL through the same client and credentials are
+// coalesced into a single HTTP request; each caller decodes its own copy of the
+// response.
+//
+// Transport failures, non-200 responses and exhausted retries are reported as a
+// *gogeek.APIError wrapping one of the sentinel errors in this package. In-band
+// <error> and <errors> documents returned with a 200 status are reported the same
+// way, wrapping ErrUserNotFound, ErrItemNotFound, ErrInvalidParameter or ErrBGGError.
+// Requests rejected by the client's circuit breaker wrap gogeek.ErrCircuitOpen.
+func FetchAndUnmarshalContext(ctx context.Context, client *gogeek.Client, url string, v interface{}) (err error) {
+	c := &call{url: url}
+	start := time.Now()
+	ctx, end := c.observe(ctx, client)
+	defer func() {
+		c.record(ctx)
+		c.logResult(ctx, client, start, err)
+		end(c.info(), err)
+	}()
+
+	raw, err := fetchBody(ctx, client, c)
+	if err != nil {
+		return err
+	}
 
-		req, err := http.NewRequest("GET", url, nil)
+	body := fixMalformedXML(raw)
+	c.rawBody, c.body = raw, body
+	if !bytes.Equal(raw, body) {
+		c.sanitised = true
+		c.log(ctx, client, slog.LevelDebug, "Sanitised malformed XML response",
+			slog.Int("original_bytes", len(raw)), slog.Int("sanitised_bytes", len(body)))
+	}
+
+	if message, ok := parseErrorDocument(body); ok {
+		apiErr := c.apiError(classifyErrorMessage(message), nil, nil)
+		apiErr.Body = truncate(string(body), maxErrorBodyLength)
+		apiErr.Message = message
+		return apiErr
+	}
+
+	if err := xml.Unmarshal(body, v); err != nil {
+		c.fallback = true
+		c.log(ctx, client, slog.LevelInfo, "Decoding XML response with fallback parser", slog.Any("error", err))
+
+		mv, err := mxj.NewMapXml(body)
 		if err != nil {
-			return ErrHTTPError
+			return ErrXMLParseError
 		}
 
-		// Add authentication headers based on client configuration
-		switch client.AuthMode() {
-		case gogeek.AuthAPIKey:
-			req.Header.Set("Authorization", "Bearer "+client.APIKey())
-		case gogeek.AuthCookie:
-			req.Header.Set("Cookie", client.CookieString())
+		cleanXML, err := mv.Xml()
+		if err != nil {
+			return ErrRegenerateError
 		}
 
-		resp, err := http.DefaultClient.Do(req)
-		if err != nil {
-			return ErrHTTPError
+		if err := xml.Unmarshal(cleanXML, v); err != nil {
+			typeName := fmt.Sprintf("%T", v)
+			return fmt.Errorf("%w: failed to unmarshal into %s: %v", ErrUnmarshalError, typeName, err)
 		}
+	}
 
-		// Handle 202 status - request accepted but still processing
-		// https://boardgamegeek.com/wiki/page/BGG_XML_API2#toc12
-		if resp.StatusCode == http.StatusAccepted {
-			resp.Body.Close()
-			if attempt == maxRetries {
-				return ErrMaxRetriesExceeded
-			}
-			time.Sleep(retryDelay)
-			continue
+	if !c.cacheHit {
+		storeBody(client, c, raw)
+	}
+
+	return nil // Success
+}
+
+// fetchBody returns the raw response body for the call, from the client's cache if
+// possible, otherwise from BGG. Concurrent identical calls share a single request.
+func fetchBody(ctx context.Context, client *gogeek.Client, c *call) ([]byte, error) {
+	if body, ok := cachedBody(ctx, client, c); ok {
+		c.cacheHit = true
+		c.statusCode = http.StatusOK
+		return body, nil
+	}
+
+	body, stats, shared, err := flights.do(ctx, flightKey(ctx, client, c.url), func(ctx context.Context, fc *call) ([]byte, error) {
+		fc.url = c.url
+		return fetchRemote(ctx, client, fc)
+	})
+
+	c.attempts = stats.attempts
+	c.retries = stats.retries
+	c.statusCode = stats.statusCode
+	c.header = stats.header
+	c.queuedFor = stats.queuedFor
+	c.shared = shared
+
+	return body, err
+}
+
+// fetchRemote requests the call's URL from BGG and reads the response body
+func fetchRemote(ctx context.Context, client *gogeek.Client, c *call) ([]byte, error) {
+	resp, err := do(ctx, client, c)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	c.header = resp.Header
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		var decompressErr *decompressError
+		if errors.As(err, &decompressErr) {
+			return nil, c.apiError(ErrHTTPError, err, nil)
 		}
+		return nil, c.apiError(ErrEmptyResponse, err, nil)
+	}
 
-		defer resp.Body.Close()
+	return body, nil
+}
 
-		if resp.StatusCode != http.StatusOK {
-			return fmt.Errorf("%w: %d", ErrUnexpectedStatusCode, resp.StatusCode)
+// call tracks the progress of a single request across queued polls and retries
+type call struct {
+	url        string
+	attempts   int
+	retries    int
+	statusCode int
+	queuedFor  time.Duration
+	cacheHit   bool
+	shared     bool
+	sanitised  bool
+	fallback   bool
+	header     http.Header
+	rawBody    []byte
+	body       []byte
+}
+
+// do sends the request until BGG returns 200, polling queued (202) responses and
+// retrying retryable statuses. On success the caller must close the response body.
+func do(ctx context.Context, client *gogeek.Client, c *call) (*http.Response, error) {
+	retryPolicy := client.RetryPolicy()
+	queuePolicy := client.QueuePolicyFor(ctx)
+	polls := 0
+	var queueStart time.Time
+
+	for {
+		// Fail fast rather than waiting on the rate limiter for a request that would be rejected
+		if breaker := client.CircuitBreaker(); breaker != nil && breaker.State() == gogeek.CircuitOpen {
+			return nil, c.apiError(gogeek.ErrCircuitOpen, nil, nil)
+		}
+
+		if err := waitForLimiters(ctx, client, c); err != nil {
+			return nil, err
 		}
 
-		body, err := io.ReadAll(resp.Body)
+		attemptCtx := gogeek.ContextWithRequestInfo(ctx, gogeek.RequestInfo{
+			Endpoint: endpointName(c.url),
+			URL:      gogeek.RedactURL(c.url),
+			Attempt:  c.attempts + 1,
+		})
+
+		req, err := http.NewRequestWithContext(attemptCtx, "GET", c.url, nil)
 		if err != nil {
-			return ErrEmptyResponse
+			return nil, c.apiError(ErrHTTPError, err, nil)
 		}
 
-		body = fixMalformedXML(body)
+		setHeaders(req, client)
 
-		if err := xml.Unmarshal(body, v); err != nil {
-			mv, err := mxj.NewMapXml(body)
-			if err != nil {
-				return ErrXMLParseError
+		c.attempts++
+		resp, err := client.Doer().Do(req)
+		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return nil, ctxErr
+			}
+			if errors.Is(err, gogeek.ErrCircuitOpen) {
+				return nil, c.apiError(gogeek.ErrCircuitOpen, nil, nil)
+			}
+			return nil, c.apiError(ErrHTTPError, err, nil)
+		}
+		c.statusCode = resp.StatusCode
+
+		decompress(resp)
+
+		// Handle 202 status - request accepted but still processing
+		// https://boardgamegeek.com/wiki/page/BGG_XML_API2#toc12
+		if resp.StatusCode == http.StatusAccepted {
+			// The deadline runs from the first 202, so round trips and limiter waits count towards it
+			if queueStart.IsZero() {
+				queueStart = time.Now()
 			}
+			c.queuedFor = time.Since(queueStart)
 
-			cleanXML, err := mv.Xml()
+			delay := queuePolicy.Delay(polls)
+			if queuePolicy.MaxPolls > 0 && polls >= queuePolicy.MaxPolls {
+				return nil, c.apiError(ErrMaxRetriesExceeded, nil, resp)
+			}
+			if deadline := queuePolicy.Deadline(); deadline > 0 && c.queuedFor+delay > deadline {
+				return nil, c.apiError(ErrMaxRetriesExceeded, ErrQueueTimeout, resp)
+			}
+			polls++
+			resp.Body.Close()
+			c.log(ctx, client, slog.LevelDebug, "BGG queued request, polling",
+				slog.Int("status", resp.StatusCode), slog.Int("poll", polls),
+				slog.Duration("delay", delay), slog.Duration("queued_for", c.queuedFor))
+
+			err := sleepContext(ctx, delay)
+			c.queuedFor = time.Since(queueStart)
 			if err != nil {
-				return ErrRegenerateError
+				return nil, err
 			}
+			continue
+		}
 
-			if err := xml.Unmarshal(cleanXML, v); err != nil {
-				typeName := fmt.Sprintf("%T", v)
-				return fmt.Errorf("%w: failed to unmarshal into %s: %v", ErrUnmarshalError, typeName, err)
+		if resp.StatusCode != http.StatusOK {
+			if !retryPolicy.Retryable(resp.StatusCode) || c.retries+1 >= retryPolicy.MaxAttempts {
+				return nil, c.apiError(ErrUnexpectedStatusCode, nil, resp)
+			}
+			retryAfter := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+			delay := retryPolicy.Delay(c.retries, retryAfter)
+			c.retries++
+			resp.Body.Close()
+			c.log(ctx, client, slog.LevelWarn, "Retrying BGG request",
+				slog.Int("status", resp.StatusCode), slog.Int("retry", c.retries),
+				slog.Duration("delay", delay), slog.Duration("retry_after", retryAfter))
+			if err := sleepContext(ctx, delay); err != nil {
+				return nil, err
 			}
+			continue
 		}
 
-		return nil // Success
+		return resp, nil
+	}
+}
+
+// apiError builds a *gogeek.APIError for the call, capturing the status code,
+// Retry-After header and an excerpt of the body when a response is available.
+// The response body is closed.
+func (c *call) apiError(sentinel error, cause error, resp *http.Response) *gogeek.APIError {
+	apiErr := &gogeek.APIError{
+		Err:        sentinel,
+		Cause:      cause,
+		StatusCode: c.statusCode,
+		URL:        gogeek.RedactURL(c.url),
+		Attempts:   c.attempts,
+		QueuedFor:  c.queuedFor,
 	}
 
-	return fmt.Errorf("failed to get response from BGG API after retries")
+	if resp != nil {
+		defer resp.Body.Close()
+
+		apiErr.RetryAfter = parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+
+		excerpt, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodyLength))
+		apiErr.Body = string(excerpt)
+		if message, ok := parseErrorDocument(excerpt); ok {
+			apiErr.Message = message
+		}
+	}
+
+	return apiErr
 }
 
-func fixMalformedXML(data []byte) []byte {
-	xmlStr := string(data)
+// info describes how the call was served
+func (c *call) info() gogeek.ResponseInfo {
+	return gogeek.ResponseInfo{
+		URL:        gogeek.RedactURL(c.url),
+		StatusCode: c.statusCode,
+		Attempts:   c.attempts,
+		Retries:    c.retries,
+		QueuedFor:  c.queuedFor,
+		CacheHit:   c.cacheHit,
+		Shared:     c.shared,
+	}
+}
 
-	cdataRegex := regexp.MustCompile(`<!\[CDATA\[(.*?)\]\]>`)
-	cdataSections := make(map[string]string)
-	xmlStr = cdataRegex.ReplaceAllStringFunc(xmlStr, func(match string) string {
-		placeholder := fmt.Sprintf("CDATA_PLACEHOLDER_%d", len(cdataSections))
-		cdataSections[placeholder] = match
-		return placeholder
-	})
+// record fills in the gogeek.ResponseInfo carried by ctx, if any, including copies of
+// the response bodies so the caller can't modify cached or shared responses
+func (c *call) record(ctx context.Context) {
+	if info := gogeek.ResponseInfoFromContext(ctx); info != nil {
+		*info = c.info()
+		info.Header = c.header.Clone()
+		info.RawBody = bytes.Clone(c.rawBody)
+		info.Body = bytes.Clone(c.body)
+	}
+}
 
-	entityRegex := regexp.MustCompile(`&(amp|lt|gt|apos|quot|#[0-9]+|#x[0-9a-fA-F]+);`)
-	xmlStr = entityRegex.ReplaceAllStringFunc(xmlStr, func(s string) string {
-		return "ENTITY_PLACEHOLDER" + s[1:]
-	})
+// truncate shortens s to at most n bytes
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n]
+}
 
-	htmlEntityRegex := regexp.MustCompile(`&([a-zA-Z]+);`)
-	xmlStr = htmlEntityRegex.ReplaceAllStringFunc(xmlStr, func(s string) string {
+// parseRetryAfter parses a Retry-After header given either as a number of seconds
+// or as an HTTP date. It returns zero if the header is missing or invalid.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
 
-		unescaped := html.UnescapeString(s)
-		if unescaped != s {
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
 
-			return unescaped
+	if date, err := http.ParseTime(value); err == nil {
+		if d := date.Sub(now); d > 0 {
+			return d
 		}
-		return s
-	})
+	}
 
-	xmlStr = strings.Replace(xmlStr, "&", "&amp;", -1)
+	return 0
+}
 
-	xmlStr = strings.Replace(xmlStr, "ENTITY_PLACEHOLDER", "&", -1)
+// waitForLimiters blocks until the client's rate limiter, and the endpoint's
+// limiter if one is configured, allow the call to proceed or the context is done.
+func waitForLimiters(ctx context.Context, client *gogeek.Client, c *call) error {
+	endpoint := endpointName(c.url)
+	if observer := client.Observer(); observer != nil {
+		start := time.Now()
+		defer func() {
+			observer.ObserveLimiterWait(ctx, endpoint, time.Since(start))
+		}()
+	}
 
-	for placeholder, cdata := range cdataSections {
-		xmlStr = strings.Replace(xmlStr, placeholder, cdata, -1)
+	if err := client.Limiter().Wait(ctx); err != nil {
+		return err
 	}
 
-	re := regexp.MustCompile(`[\x00-\x08\x0B\x0C\x0E-\x1F]`)
-	xmlStr = re.ReplaceAllString(xmlStr, "")
+	if limiter := client.EndpointLimiter(endpoint); limiter != nil {
+		return limiter.Wait(ctx)
+	}
+
+	return nil
+}
 
-	return []byte(xmlStr)
+// sleepContext pauses for d or until the context is done, whichever happens first.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
 }
@@ -1,13 +1,21 @@
 package request
 
 import (
+	"bytes"
+	"context"
 	"encoding/xml"
 	"errors"
+	"log/slog"
 	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/jarcoal/httpmock"
 	"github.com/kkjdaniel/gogeek/v2"
+	"github.com/kkjdaniel/gogeek/v2/cache"
 	"github.com/kkjdaniel/gogeek/v2/testutils"
 	"github.com/stretchr/testify/require"
 )
@@ -33,6 +41,28 @@ func TestFetchAndUnmarshal_Success(t *testing.T) {
 	require.Equal(t, "Example Forum", result.Title, "Title should match expected value")
 }
 
+func TestFetchAndUnmarshal_ClientTransport(t *testing.T) {
+	t.Parallel()
+
+	type TestXML struct {
+		ID    int    `xml:"id,attr"`
+		Title string `xml:"title"`
+	}
+
+	testURL := "https://example.com/api/transport"
+	transport := httpmock.NewMockTransport()
+	transport.RegisterResponder("GET", testURL,
+		httpmock.NewBytesResponder(http.StatusOK, testutils.LoadTestData(t, `testdata/valid.xml`)))
+
+	var result TestXML
+	client := gogeek.NewClient(gogeek.WithTransport(transport))
+	err := FetchAndUnmarshal(client, testURL, &result)
+
+	require.NoError(t, err, "FetchAndUnmarshal should use the client's transport")
+	require.Equal(t, 123, result.ID, "ID should match expected value")
+	require.Equal(t, 1, transport.GetTotalCallCount(), "Request should go through the client's transport")
+}
+
 func TestFetchAndUnmarshal_HTTPError(t *testing.T) {
 	defer testutils.ActivateMocks()()
 
@@ -45,6 +75,12 @@ func TestFetchAndUnmarshal_HTTPError(t *testing.T) {
 
 	require.Error(t, err, "FetchAndUnmarshal should return an error when HTTP request fails")
 	require.True(t, errors.Is(err, ErrHTTPError), "Error should be of type ErrHTTPError")
+
+	var apiErr *gogeek.APIError
+	require.ErrorAs(t, err, &apiErr, "Error should be an APIError")
+	require.NotNil(t, apiErr.Cause, "APIError should keep the underlying transport error")
+	require.Contains(t, err.Error(), "failed to fetch data from BGG API", "Error should include the underlying cause")
+	require.Equal(t, 1, apiErr.Attempts, "APIError should report the attempt count")
 }
 
 func TestFetchAndUnmarshal_BadStatusCode(t *testing.T) {
@@ -62,6 +98,325 @@ func TestFetchAndUnmarshal_BadStatusCode(t *testing.T) {
 	require.Contains(t, err.Error(), "404", "Error should include the status code")
 }
 
+func TestFetchAndUnmarshal_APIErrorDetails(t *testing.T) {
+	defer testutils.ActivateMocks()()
+
+	testURL := "https://example.com/api/unavailable"
+	testutils.SetupSequentialResponders(t, testURL, []testutils.MockResponse{
+		{
+			StatusCode: http.StatusServiceUnavailable,
+			Body:       strings.Repeat("x", maxErrorBodyLength*2),
+			Headers:    map[string]string{"Retry-After": "30"},
+		},
+	})
+
+	var result struct{}
+	client := gogeek.NewClient(gogeek.WithRetryPolicy(gogeek.NoRetryPolicy()))
+	err := FetchAndUnmarshal(client, testURL, &result)
+
+	var apiErr *gogeek.APIError
+	require.ErrorAs(t, err, &apiErr, "Error should be an APIError")
+	require.ErrorIs(t, err, ErrUnexpectedStatusCode, "APIError should still match ErrUnexpectedStatusCode")
+	require.Equal(t, http.StatusServiceUnavailable, apiErr.StatusCode, "APIError should report the status code")
+	require.Equal(t, testURL, apiErr.URL, "APIError should report the request URL")
+	require.Equal(t, 30*time.Second, apiErr.RetryAfter, "APIError should report the Retry-After delay")
+	require.Len(t, apiErr.Body, maxErrorBodyLength, "APIError body should be truncated")
+}
+
+func TestFetchAndUnmarshal_InBandError(t *testing.T) {
+	defer testutils.ActivateMocks()()
+
+	testURL := "https://example.com/api/collection?username=nobody"
+	body := `<?xml version="1.0" encoding="utf-8" standalone="yes"?><errors><error><message>Invalid username specified</message></error></errors>`
+	testutils.SetupMockResponderWithBody(t, testURL, body, http.StatusOK)
+
+	var result struct {
+		TotalItems int `xml:"totalitems,attr"`
+	}
+	client := gogeek.NewClient()
+	err := FetchAndUnmarshal(client, testURL, &result)
+
+	require.ErrorIs(t, err, ErrUserNotFound, "In-band error should be reported as ErrUserNotFound")
+
+	var apiErr *gogeek.APIError
+	require.ErrorAs(t, err, &apiErr, "Error should be an APIError")
+	require.Equal(t, "Invalid username specified", apiErr.Message, "APIError should carry BGG's message")
+	require.Equal(t, http.StatusOK, apiErr.StatusCode, "APIError should report the 200 status")
+}
+
+func TestFetchAndUnmarshal_RetriesRateLimit(t *testing.T) {
+	defer testutils.ActivateMocks()()
+
+	type TestXML struct {
+		ID int `xml:"id,attr"`
+	}
+
+	testURL := "https://example.com/api/rate-limited"
+	testutils.SetupSequentialResponders(t, testURL, []testutils.MockResponse{
+		{StatusCode: http.StatusTooManyRequests, Body: "Rate limit exceeded"},
+		{StatusCode: http.StatusBadGateway, Body: ""},
+		{StatusCode: http.StatusOK, FilePath: `testdata/valid.xml`},
+	})
+
+	policy := gogeek.DefaultRetryPolicy()
+	policy.BaseDelay = 10 * time.Millisecond
+	policy.Jitter = 0
+
+	var result TestXML
+	client := gogeek.NewClient(gogeek.WithRetryPolicy(policy))
+	err := FetchAndUnmarshal(client, testURL, &result)
+
+	require.NoError(t, err, "FetchAndUnmarshal should succeed after retrying 429 and 502 responses")
+	require.Equal(t, 123, result.ID, "ID should match expected value")
+}
+
+func TestFetchAndUnmarshal_RetriesExhausted(t *testing.T) {
+	defer testutils.ActivateMocks()()
+
+	testURL := "https://example.com/api/unavailable-retries"
+	testutils.SetupSequentialResponders(t, testURL, []testutils.MockResponse{
+		{StatusCode: http.StatusServiceUnavailable, Body: ""},
+		{StatusCode: http.StatusServiceUnavailable, Body: ""},
+		{StatusCode: http.StatusServiceUnavailable, Body: ""},
+	})
+
+	policy := gogeek.RetryPolicy{
+		MaxAttempts:       3,
+		BaseDelay:         10 * time.Millisecond,
+		RetryableStatuses: []int{http.StatusServiceUnavailable},
+	}
+
+	var result struct{}
+	client := gogeek.NewClient(gogeek.WithRetryPolicy(policy))
+	err := FetchAndUnmarshal(client, testURL, &result)
+
+	var apiErr *gogeek.APIError
+	require.ErrorAs(t, err, &apiErr, "Error should be an APIError")
+	require.ErrorIs(t, err, ErrUnexpectedStatusCode, "Error should be of type ErrUnexpectedStatusCode")
+	require.Equal(t, http.StatusServiceUnavailable, apiErr.StatusCode, "APIError should report the last status code")
+	require.Equal(t, 3, apiErr.Attempts, "APIError should report every attempt")
+}
+
+func TestFetchAndUnmarshal_Cache(t *testing.T) {
+	defer testutils.ActivateMocks()()
+
+	type TestXML struct {
+		ID int `xml:"id,attr"`
+	}
+
+	testURL := "https://example.com/xmlapi2/thing?id=123"
+	testutils.SetupMockResponder(t, testURL, `testdata/valid.xml`)
+
+	client := gogeek.NewClient(gogeek.WithCache(cache.NewMemory(10)))
+
+	var first TestXML
+	var firstInfo gogeek.ResponseInfo
+	err := FetchAndUnmarshalContext(gogeek.ContextWithResponseInfo(context.Background(), &firstInfo), client, testURL, &first)
+	require.NoError(t, err, "First request should succeed")
+	require.False(t, firstInfo.CacheHit, "First request should not be served from the cache")
+
+	var second TestXML
+	var secondInfo gogeek.ResponseInfo
+	err = FetchAndUnmarshalContext(gogeek.ContextWithResponseInfo(context.Background(), &secondInfo), client, testURL, &second)
+	require.NoError(t, err, "Second request should succeed")
+	require.True(t, secondInfo.CacheHit, "Second request should be served from the cache")
+	require.Equal(t, first, second, "Cached response should decode to the same result")
+	require.Equal(t, 1, httpmock.GetTotalCallCount(), "Only the first request should reach BGG")
+
+	var bypassed TestXML
+	err = FetchAndUnmarshalContext(gogeek.ContextWithoutCache(context.Background()), client, testURL, &bypassed)
+	require.NoError(t, err, "Bypassed request should succeed")
+	require.Equal(t, 2, httpmock.GetTotalCallCount(), "Bypassed request should reach BGG")
+}
+
+func TestFetchAndUnmarshal_CacheSkipsErrors(t *testing.T) {
+	defer testutils.ActivateMocks()()
+
+	testURL := "https://example.com/xmlapi2/user?name=nobody"
+	testutils.SetupMockResponderWithBody(t, testURL, `<error message="Invalid username specified"/>`, http.StatusOK)
+
+	memoryCache := cache.NewMemory(10)
+	client := gogeek.NewClient(gogeek.WithCache(memoryCache))
+
+	var result struct{}
+	err := FetchAndUnmarshal(client, testURL, &result)
+
+	require.ErrorIs(t, err, ErrUserNotFound, "In-band error should be returned")
+	require.Equal(t, 0, memoryCache.Len(), "Error responses should not be cached")
+}
+
+func TestFetchAndUnmarshal_Middleware(t *testing.T) {
+	defer testutils.ActivateMocks()()
+
+	testURL := "https://example.com/xmlapi2/thing?id=123"
+	testutils.SetupSequentialResponders(t, testURL, []testutils.MockResponse{
+		{StatusCode: http.StatusAccepted, Body: ""},
+		{StatusCode: http.StatusOK, FilePath: `testdata/valid.xml`},
+	})
+
+	var seen []gogeek.RequestInfo
+	var statuses []int
+	middleware := func(next gogeek.Doer) gogeek.Doer {
+		return gogeek.DoerFunc(func(req *http.Request) (*http.Response, error) {
+			info, _ := gogeek.RequestInfoFromContext(req.Context())
+			seen = append(seen, info)
+			resp, err := next.Do(req)
+			if resp != nil {
+				statuses = append(statuses, resp.StatusCode)
+			}
+			return resp, err
+		})
+	}
+
+	var result struct{}
+	client := gogeek.NewClient(
+		gogeek.WithMiddleware(middleware),
+		gogeek.WithQueuePolicy(gogeek.QueuePolicy{MaxPolls: 1, InitialDelay: 10 * time.Millisecond}),
+	)
+	err := FetchAndUnmarshal(client, testURL, &result)
+
+	require.NoError(t, err, "FetchAndUnmarshal should succeed through the middleware")
+	require.Equal(t, []gogeek.RequestInfo{
+		{Endpoint: "thing", URL: testURL, Attempt: 1},
+		{Endpoint: "thing", URL: testURL, Attempt: 2},
+	}, seen, "Middleware should see every attempt")
+	require.Equal(t, []int{http.StatusAccepted, http.StatusOK}, statuses, "Middleware should see every response")
+}
+
+func TestFetchAndUnmarshal_RawResponse(t *testing.T) {
+	defer testutils.ActivateMocks()()
+
+	testURL := "https://example.com/xmlapi2/thing?id=123"
+	raw := `<item id="123" unmodelled="kept"><title>Rock & Roll</title></item>`
+	resp := httpmock.NewStringResponse(http.StatusOK, raw)
+	resp.Header.Set("Last-Modified", "Wed, 01 Jan 2025 12:00:00 GMT")
+	httpmock.RegisterResponder("GET", testURL, httpmock.ResponderFromResponse(resp))
+
+	type TestXML struct {
+		Title string `xml:"title"`
+	}
+
+	client := gogeek.NewClient(gogeek.WithCache(cache.NewMemory(10)))
+
+	var info gogeek.ResponseInfo
+	var result TestXML
+	err := FetchAndUnmarshalContext(gogeek.ContextWithResponseInfo(context.Background(), &info), client, testURL, &result)
+
+	require.NoError(t, err, "FetchAndUnmarshalContext should succeed")
+	require.Equal(t, "Rock & Roll", result.Title, "Response should be decoded")
+	require.Equal(t, raw, string(info.RawBody), "Raw body should be captured unmodified")
+	require.Equal(t, `<item id="123" unmodelled="kept"><title>Rock &amp; Roll</title></item>`, string(info.Body), "Sanitised body should be captured")
+	require.Equal(t, "Wed, 01 Jan 2025 12:00:00 GMT", info.Header.Get("Last-Modified"), "Response header should be captured")
+
+	// Modifying the captured body must not affect the cached response
+	info.RawBody[0] = 'X'
+
+	var cached gogeek.ResponseInfo
+	err = FetchAndUnmarshalContext(gogeek.ContextWithResponseInfo(context.Background(), &cached), client, testURL, &result)
+
+	require.NoError(t, err, "Cached call should succeed")
+	require.True(t, cached.CacheHit, "Second call should be served from the cache")
+	require.Equal(t, raw, string(cached.RawBody), "Raw body should be captured from the cache")
+	require.Nil(t, cached.Header, "Cached responses have no header")
+}
+
+func TestFetchAndUnmarshal_CircuitBreaker(t *testing.T) {
+	defer testutils.ActivateMocks()()
+
+	testURL := "https://example.com/xmlapi2/thing?id=123"
+	httpmock.RegisterResponder("GET", testURL, httpmock.NewStringResponder(http.StatusServiceUnavailable, ""))
+
+	breaker := gogeek.NewCircuitBreaker(gogeek.CircuitBreakerPolicy{FailureRatio: 1, MinRequests: 2, OpenTimeout: time.Minute})
+	client := gogeek.NewClient(
+		gogeek.WithCircuitBreaker(breaker),
+		gogeek.WithRetryPolicy(gogeek.RetryPolicy{MaxAttempts: 4, RetryableStatuses: []int{http.StatusServiceUnavailable}}),
+	)
+
+	var result struct{}
+	err := FetchAndUnmarshal(client, testURL, &result)
+
+	require.ErrorIs(t, err, gogeek.ErrCircuitOpen, "Retries should stop once the breaker trips")
+	require.Equal(t, 2, httpmock.GetTotalCallCount(), "No requests should be sent once the breaker is open")
+	require.Equal(t, gogeek.CircuitOpen, breaker.State(), "Breaker should be open")
+
+	start := time.Now()
+	err = FetchAndUnmarshal(client, testURL, &result)
+
+	require.ErrorIs(t, err, gogeek.ErrCircuitOpen, "Calls should fail fast while the breaker is open")
+	require.Less(t, time.Since(start), 100*time.Millisecond, "Calls should not wait on the rate limiter while the breaker is open")
+	require.Equal(t, 2, httpmock.GetTotalCallCount(), "No requests should be sent while the breaker is open")
+}
+
+func TestFetchAndUnmarshal_Logging(t *testing.T) {
+	defer testutils.ActivateMocks()()
+
+	testURL := "https://example.com/xmlapi2/thing?id=123&apikey=secret"
+	httpmock.RegisterResponder("GET", testURL, httpmock.ResponderFromMultipleResponses([]*http.Response{
+		httpmock.NewStringResponse(http.StatusAccepted, ""),
+		httpmock.NewStringResponse(http.StatusOK, `<item id="123"><name>Dungeons & Dragons</name></item>`),
+	}))
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	client := gogeek.NewClient(
+		gogeek.WithLogger(logger),
+		gogeek.WithQueuePolicy(gogeek.QueuePolicy{MaxPolls: 1, InitialDelay: 10 * time.Millisecond}),
+	)
+
+	var result struct {
+		Name string `xml:"name"`
+	}
+	err := FetchAndUnmarshal(client, testURL, &result)
+
+	require.NoError(t, err, "FetchAndUnmarshal should succeed")
+	require.Equal(t, "Dungeons & Dragons", result.Name, "Sanitised response should be decoded")
+
+	logs := buf.String()
+	require.NotContains(t, logs, "secret", "Logged URLs should be redacted")
+	require.Contains(t, logs, `msg="BGG queued request, polling" endpoint=thing`, "Queued polls should be logged")
+	require.Contains(t, logs, "status=202 poll=1", "Queued polls should log the status and poll")
+	require.Contains(t, logs, `msg="Sanitised malformed XML response"`, "XML repairs should be logged")
+	require.Contains(t, logs, `msg="BGG request completed"`, "The outcome should be logged")
+	require.Contains(t, logs, "attempt=2 status=200", "The outcome should log the attempts and status")
+	require.Contains(t, logs, "sanitised=true fallback_parser=false", "The outcome should report sanitisation")
+}
+
+func TestFetchAndUnmarshal_LoggingFailure(t *testing.T) {
+	defer testutils.ActivateMocks()()
+
+	testURL := "https://example.com/xmlapi2/thing?id=123"
+	httpmock.RegisterResponder("GET", testURL, httpmock.NewStringResponder(http.StatusServiceUnavailable, ""))
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+	client := gogeek.NewClient(
+		gogeek.WithLogger(logger),
+		gogeek.WithRetryPolicy(gogeek.RetryPolicy{MaxAttempts: 2, RetryableStatuses: []int{http.StatusServiceUnavailable}}),
+	)
+
+	var result struct{}
+	err := FetchAndUnmarshal(client, testURL, &result)
+
+	require.Error(t, err, "FetchAndUnmarshal should fail")
+
+	logs := buf.String()
+	require.Contains(t, logs, `level=WARN msg="Retrying BGG request" endpoint=thing`, "Retries should be logged")
+	require.Contains(t, logs, "status=503 retry=1", "Retries should log the status and retry")
+	require.Contains(t, logs, `level=WARN msg="BGG request failed"`, "Failures should be logged")
+	require.NotContains(t, logs, "BGG request completed", "Debug events should respect the handler level")
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	require.Equal(t, time.Duration(0), parseRetryAfter("", now), "Missing header should be zero")
+	require.Equal(t, 120*time.Second, parseRetryAfter("120", now), "Seconds should be parsed")
+	require.Equal(t, time.Duration(0), parseRetryAfter("-5", now), "Negative seconds should be zero")
+	require.Equal(t, 90*time.Second, parseRetryAfter("Wed, 01 Jan 2025 12:01:30 GMT", now), "HTTP dates should be parsed")
+	require.Equal(t, time.Duration(0), parseRetryAfter("Wed, 01 Jan 2025 11:00:00 GMT", now), "Past dates should be zero")
+	require.Equal(t, time.Duration(0), parseRetryAfter("soon", now), "Invalid values should be zero")
+}
+
 func TestFetchAndUnmarshal_InvalidXML(t *testing.T) {
 	defer testutils.ActivateMocks()()
 
@@ -100,10 +455,6 @@ func TestFetchAndUnmarshal_UnmarshalError(t *testing.T) {
 func TestFetchAndUnmarshal_Status202_EventualSuccess(t *testing.T) {
 	defer testutils.ActivateMocks()()
 
-	originalRetryDelay := retryDelay
-	retryDelay = 100 * time.Millisecond
-	defer func() { retryDelay = originalRetryDelay }()
-
 	type TestXML struct {
 		XMLName xml.Name `xml:"forum"`
 		ID      int      `xml:"id,attr"`
@@ -120,41 +471,195 @@ func TestFetchAndUnmarshal_Status202_EventualSuccess(t *testing.T) {
 	})
 
 	var result TestXML
-	client := gogeek.NewClient()
-	err := FetchAndUnmarshal(client, testURL, &result)
+	var info gogeek.ResponseInfo
+	ctx := gogeek.ContextWithResponseInfo(context.Background(), &info)
+	client := gogeek.NewClient(gogeek.WithQueuePolicy(gogeek.QueuePolicy{
+		MaxPolls:     5,
+		InitialDelay: 100 * time.Millisecond,
+	}))
+	err := FetchAndUnmarshalContext(ctx, client, testURL, &result)
 
 	require.NoError(t, err, "FetchAndUnmarshal should eventually succeed after 202 responses")
 	require.Equal(t, 123, result.ID, "ID should match expected value")
 	require.Equal(t, "Example Forum", result.Title, "Title should match expected value")
+	require.Equal(t, 3, info.Attempts, "ResponseInfo should report every attempt")
+	require.Equal(t, http.StatusOK, info.StatusCode, "ResponseInfo should report the final status")
+	require.GreaterOrEqual(t, info.QueuedFor, 200*time.Millisecond, "ResponseInfo should report the time spent queued")
 }
 
 func TestFetchAndUnmarshal_Status202_ExceedsRetries(t *testing.T) {
 	defer testutils.ActivateMocks()()
 
-	originalMaxRetries := maxRetries
-	originalRetryDelay := retryDelay
-	maxRetries = 3
-	retryDelay = 100 * time.Millisecond
-	defer func() {
-		maxRetries = originalMaxRetries
-		retryDelay = originalRetryDelay
-	}()
+	policy := gogeek.QueuePolicy{
+		MaxPolls:     3,
+		InitialDelay: 100 * time.Millisecond,
+	}
 
 	testURL := "https://example.com/api/always-queued"
 
-	responses := make([]testutils.MockResponse, maxRetries+1)
+	responses := make([]testutils.MockResponse, policy.MaxPolls+1)
 	for i := range responses {
 		responses[i] = testutils.MockResponse{StatusCode: http.StatusAccepted, Body: ""}
 	}
 	testutils.SetupSequentialResponders(t, testURL, responses)
 
 	var result struct{}
-	client := gogeek.NewClient()
+	client := gogeek.NewClient(gogeek.WithQueuePolicy(policy))
 	err := FetchAndUnmarshal(client, testURL, &result)
 
 	require.Error(t, err, "FetchAndUnmarshal should fail after exceeding retries")
 	require.True(t, errors.Is(err, ErrMaxRetriesExceeded), "Error should be of type ErrMaxRetriesExceeded")
 	require.Contains(t, err.Error(), "exceeded maximum retries")
+
+	var apiErr *gogeek.APIError
+	require.ErrorAs(t, err, &apiErr, "Error should be an APIError")
+	require.GreaterOrEqual(t, apiErr.QueuedFor, 300*time.Millisecond, "APIError should report the time spent queued")
+}
+
+func TestFetchAndUnmarshal_Status202_QueueTimeout(t *testing.T) {
+	defer testutils.ActivateMocks()()
+
+	testURL := "https://example.com/api/slow-queue"
+	testutils.SetupSequentialResponders(t, testURL, []testutils.MockResponse{
+		{StatusCode: http.StatusAccepted, Body: ""},
+		{StatusCode: http.StatusAccepted, Body: ""},
+		{StatusCode: http.StatusAccepted, Body: ""},
+	})
+
+	var result struct{}
+	client := gogeek.NewClient(gogeek.WithQueuePolicy(gogeek.DefaultQueuePolicy()), gogeek.WithRateLimit(1000, 10))
+	ctx := gogeek.ContextWithQueuePolicy(context.Background(), gogeek.QueuePolicy{
+		InitialDelay: 50 * time.Millisecond,
+		Multiplier:   2,
+		Timeout:      200 * time.Millisecond,
+	})
+	err := FetchAndUnmarshalContext(ctx, client, testURL, &result)
+
+	require.ErrorIs(t, err, ErrMaxRetriesExceeded, "Queue timeout should be reported as ErrMaxRetriesExceeded")
+	require.ErrorIs(t, err, ErrQueueTimeout, "Queue timeout should be reported as ErrQueueTimeout")
+
+	var apiErr *gogeek.APIError
+	require.ErrorAs(t, err, &apiErr, "Error should be an APIError")
+	require.Equal(t, 3, apiErr.Attempts, "Per-call policy should poll until the next delay exceeds the timeout")
+}
+
+func TestFetchAndUnmarshal_Status202_TimeoutIncludesRoundTrips(t *testing.T) {
+	var polls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		polls.Add(1)
+		time.Sleep(40 * time.Millisecond)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	var result struct{}
+	client := gogeek.NewClient(gogeek.WithRateLimit(1000, 10))
+	ctx := gogeek.ContextWithQueuePolicy(context.Background(), gogeek.QueuePolicy{
+		InitialDelay: 10 * time.Millisecond,
+		Timeout:      150 * time.Millisecond,
+	})
+	err := FetchAndUnmarshalContext(ctx, client, server.URL+"/xmlapi2/thing?id=1", &result)
+
+	require.ErrorIs(t, err, ErrQueueTimeout, "Queue timeout should be reported as ErrQueueTimeout")
+	require.LessOrEqual(t, polls.Load(), int32(4), "Time spent on round trips should count towards the timeout")
+
+	var apiErr *gogeek.APIError
+	require.ErrorAs(t, err, &apiErr, "Error should be an APIError")
+	require.GreaterOrEqual(t, apiErr.QueuedFor, 80*time.Millisecond, "QueuedFor should include round trips")
+}
+
+func TestFetchAndUnmarshal_Status202_PartialPolicy(t *testing.T) {
+	var polls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		polls.Add(1)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+	ctx = gogeek.ContextWithQueuePolicy(ctx, gogeek.QueuePolicy{Timeout: time.Minute})
+
+	var result struct{}
+	client := gogeek.NewClient(gogeek.WithRateLimit(1000, 10))
+	err := FetchAndUnmarshalContext(ctx, client, server.URL+"/xmlapi2/thing?id=1", &result)
+
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Equal(t, int32(1), polls.Load(), "A zero initial delay should fall back to the default rather than polling without delay")
+}
+
+func TestFetchAndUnmarshal_Status202_DelayOnlyPolicy(t *testing.T) {
+	var polls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		polls.Add(1)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	// The first delay already exceeds the default deadline, so polling should stop straight away
+	var result struct{}
+	client := gogeek.NewClient(gogeek.WithQueuePolicy(gogeek.QueuePolicy{InitialDelay: 2 * time.Minute}))
+	err := FetchAndUnmarshal(client, server.URL+"/xmlapi2/thing?id=1", &result)
+
+	require.ErrorIs(t, err, ErrQueueTimeout, "A policy without a timeout should use the default deadline")
+	require.Equal(t, int32(1), polls.Load())
+}
+
+func TestFetchAndUnmarshalContext_Cancelled(t *testing.T) {
+	defer testutils.ActivateMocks()()
+
+	testURL := "https://example.com/api/cancelled"
+	testutils.SetupMockResponder(t, testURL, `testdata/valid.xml`)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var result struct{}
+	client := gogeek.NewClient()
+	err := FetchAndUnmarshalContext(ctx, client, testURL, &result)
+
+	require.ErrorIs(t, err, context.Canceled, "Cancelled context should abort the request")
+}
+
+func TestFetchAndUnmarshalContext_CancelledDuringLimiterWait(t *testing.T) {
+	defer testutils.ActivateMocks()()
+
+	testURL := "https://example.com/api/limited"
+	testutils.SetupMockResponder(t, testURL, `testdata/valid.xml`)
+
+	client := gogeek.NewClient()
+	client.Limiter().Wait(context.Background())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	var result struct{}
+	start := time.Now()
+	err := FetchAndUnmarshalContext(ctx, client, testURL, &result)
+
+	require.ErrorIs(t, err, context.DeadlineExceeded, "Deadline should abort the limiter wait")
+	require.Less(t, time.Since(start), 400*time.Millisecond, "Should not wait for the limiter once the deadline passes")
+}
+
+func TestFetchAndUnmarshalContext_CancelledDuring202Backoff(t *testing.T) {
+	defer testutils.ActivateMocks()()
+
+	testURL := "https://example.com/api/queued-cancel"
+	testutils.SetupSequentialResponders(t, testURL, []testutils.MockResponse{
+		{StatusCode: http.StatusAccepted, Body: ""},
+		{StatusCode: http.StatusOK, FilePath: `testdata/valid.xml`},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	var result struct{}
+	client := gogeek.NewClient()
+	start := time.Now()
+	err := FetchAndUnmarshalContext(ctx, client, testURL, &result)
+
+	require.ErrorIs(t, err, context.DeadlineExceeded, "Deadline should abort the 202 back-off")
+	require.Less(t, time.Since(start), client.QueuePolicy().InitialDelay, "Should not sleep the full queue delay")
 }
 
 func TestFixMalformedXML(t *testing.T) {
@@ -0,0 +1,230 @@
+package request
+
+import (
+	"bufio"
+	"bytes"
+	"html"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxEntityLength is the longest entity reference the sanitiser recognises, long
+// enough for every HTML named entity (e.g. &CounterClockwiseContourIntegral;)
+const maxEntityLength = 40
+
+// verbatimSections are the sections copied through without entity handling, by their
+// opening and closing delimiters: CDATA sections, comments and processing instructions
+var verbatimSections = [][2][]byte{
+	{[]byte("<![CDATA["), []byte("]]>")},
+	{[]byte("<!--"), []byte("-->")},
+	{[]byte("<?"), []byte("?>")},
+}
+
+// sanitiser is an io.Reader that repairs the malformed XML BGG sometimes returns in
+// a single pass over the underlying reader. It:
+//
+//   - escapes ampersands that don't start a valid entity reference
+//   - converts HTML named entities such as &eacute; to the characters they represent
+//   - escapes character references to characters XML forbids
+//   - removes control characters and other characters XML forbids
+//   - replaces invalid UTF-8 with U+FFFD
+//   - copies CDATA sections, comments and processing instructions through unchanged
+//     apart from forbidden characters
+type sanitiser struct {
+	r   *bufio.Reader
+	out []byte
+	end []byte // closing delimiter of the verbatim section being copied, if any
+	err error
+}
+
+// newSanitiser returns a reader yielding the sanitised contents of r
+func newSanitiser(r io.Reader) *sanitiser {
+	return &sanitiser{r: bufio.NewReader(r)}
+}
+
+// fixMalformedXML returns data with BGG's XML errors repaired. See sanitiser.
+func fixMalformedXML(data []byte) []byte {
+	fixed, _ := io.ReadAll(newSanitiser(bytes.NewReader(data)))
+	return fixed
+}
+
+func (s *sanitiser) Read(p []byte) (int, error) {
+	for len(s.out) == 0 && s.err == nil {
+		s.err = s.step()
+	}
+
+	n := copy(p, s.out)
+	s.out = s.out[n:]
+	if len(s.out) > 0 {
+		return n, nil
+	}
+	return n, s.err
+}
+
+// step sanitises the next character, section delimiter or entity reference
+func (s *sanitiser) step() error {
+	if s.end != nil {
+		if s.consume(s.end) {
+			s.out = append(s.out, s.end...)
+			s.end = nil
+			return nil
+		}
+		return s.copyRune()
+	}
+
+	b, err := s.r.Peek(1)
+	if err != nil {
+		return err
+	}
+
+	switch b[0] {
+	case '<':
+		for _, section := range verbatimSections {
+			if s.consume(section[0]) {
+				s.out = append(s.out, section[0]...)
+				s.end = section[1]
+				return nil
+			}
+		}
+	case '&':
+		s.entity()
+		return nil
+	}
+
+	return s.copyRune()
+}
+
+// consume discards prefix from the input and returns true if the input starts with it
+func (s *sanitiser) consume(prefix []byte) bool {
+	b, _ := s.r.Peek(len(prefix))
+	if !bytes.Equal(b, prefix) {
+		return false
+	}
+	s.r.Discard(len(prefix))
+	return true
+}
+
+// copyRune copies the next character to the output, dropping characters XML forbids
+func (s *sanitiser) copyRune() error {
+	r, size, err := s.r.ReadRune()
+	if err != nil {
+		return err
+	}
+
+	if r == utf8.RuneError && size == 1 {
+		s.out = utf8.AppendRune(s.out, utf8.RuneError)
+		return nil
+	}
+
+	if isXMLChar(r) {
+		s.out = utf8.AppendRune(s.out, r)
+	}
+	return nil
+}
+
+// entity sanitises the entity reference at the start of the input, which begins with '&'
+func (s *sanitiser) entity() {
+	peek, _ := s.r.Peek(maxEntityLength)
+	end := bytes.IndexByte(peek, ';')
+	if end < 0 || !isEntityName(peek[1:end]) {
+		s.r.Discard(1)
+		s.out = append(s.out, "&amp;"...)
+		return
+	}
+
+	ref := peek[:end+1]
+	name := string(ref[1:end])
+
+	switch {
+	case name == "amp" || name == "lt" || name == "gt" || name == "apos" || name == "quot":
+		s.out = append(s.out, ref...)
+	case name[0] == '#':
+		if validCharRef(name[1:]) {
+			s.out = append(s.out, ref...)
+		} else {
+			s.out = append(s.out, "&amp;"...)
+			s.out = append(s.out, ref[1:]...)
+		}
+	default:
+		// UnescapeString also decodes legacy entities without a semicolon, such as the
+		// "&not" in "&notanentity;", so check the whole reference was consumed
+		unescaped := html.UnescapeString(string(ref))
+		if unescaped == string(ref) || strings.HasSuffix(unescaped, ";") && name != "semi" {
+			s.out = append(s.out, "&amp;"...)
+			s.out = append(s.out, ref[1:]...)
+			break
+		}
+		for _, r := range unescaped {
+			s.appendEscaped(r)
+		}
+	}
+
+	s.r.Discard(len(ref))
+}
+
+// appendEscaped appends r, escaping it if it is markup and dropping it if XML forbids it
+func (s *sanitiser) appendEscaped(r rune) {
+	switch r {
+	case '<':
+		s.out = append(s.out, "&lt;"...)
+	case '>':
+		s.out = append(s.out, "&gt;"...)
+	case '&':
+		s.out = append(s.out, "&amp;"...)
+	case '"':
+		s.out = append(s.out, "&quot;"...)
+	case '\'':
+		s.out = append(s.out, "&apos;"...)
+	default:
+		if isXMLChar(r) {
+			s.out = utf8.AppendRune(s.out, r)
+		}
+	}
+}
+
+// isEntityName reports whether name could be the body of an entity reference:
+// a run of ASCII letters and digits, optionally starting with '#'
+func isEntityName(name []byte) bool {
+	if len(name) > 0 && name[0] == '#' {
+		name = name[1:]
+	}
+	if len(name) == 0 {
+		return false
+	}
+
+	for _, b := range name {
+		if !('a' <= b && b <= 'z' || 'A' <= b && b <= 'Z' || '0' <= b && b <= '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// validCharRef reports whether ref, the part of a character reference after "&#",
+// refers to a character XML allows
+func validCharRef(ref string) bool {
+	base := 10
+	if len(ref) > 0 && (ref[0] == 'x') {
+		ref, base = ref[1:], 16
+	}
+
+	for _, b := range []byte(ref) {
+		if base == 10 && !('0' <= b && b <= '9') {
+			return false
+		}
+	}
+
+	n, err := strconv.ParseUint(ref, base, 32)
+	return err == nil && isXMLChar(rune(n))
+}
+
+// isXMLChar reports whether r is allowed in an XML document
+// https://www.w3.org/TR/xml/#charsets
+func isXMLChar(r rune) bool {
+	return r == '\t' || r == '\n' || r == '\r' ||
+		r >= 0x20 && r <= 0xD7FF ||
+		r >= 0xE000 && r <= 0xFFFD ||
+		r >= 0x10000 && r <= 0x10FFFF
+}
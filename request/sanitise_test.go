@@ -0,0 +1,162 @@
+package request
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/require"
+)
+
+// wellFormed returns an error if data is not well-formed XML
+func wellFormed(data []byte) error {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		_, err := decoder.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func TestSanitiser(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "Placeholder-like text is preserved",
+			input:    `<item>CDATA_PLACEHOLDER_0 ENTITY_PLACEHOLDERamp; & co</item>`,
+			expected: `<item>CDATA_PLACEHOLDER_0 ENTITY_PLACEHOLDERamp; &amp; co</item>`,
+		},
+		{
+			name:     "CDATA sections are preserved",
+			input:    `<description><![CDATA[Rock & Roll &eacute; <b>bold</b>]]> & more</description>`,
+			expected: `<description><![CDATA[Rock & Roll &eacute; <b>bold</b>]]> &amp; more</description>`,
+		},
+		{
+			name:     "Control characters are stripped from CDATA",
+			input:    "<description><![CDATA[a\x01b]]></description>",
+			expected: "<description><![CDATA[ab]]></description>",
+		},
+		{
+			name:     "HTML entities are converted",
+			input:    `<name>Caf&eacute; &mdash; &nbsp;Stra&szlig;e</name>`,
+			expected: "<name>Café —  Straße</name>",
+		},
+		{
+			name:     "HTML entities for markup are escaped",
+			input:    `<name value="a &QUOT;quoted&QUOT; &LT;tag&GT; &AMP; b"/>`,
+			expected: `<name value="a &quot;quoted&quot; &lt;tag&gt; &amp; b"/>`,
+		},
+		{
+			name:     "Unknown entities are escaped",
+			input:    `<name>&notanentity; &;  &# &#x;</name>`,
+			expected: `<name>&amp;notanentity; &amp;;  &amp;# &amp;#x;</name>`,
+		},
+		{
+			name:     "Character references to forbidden characters are escaped",
+			input:    `<name>&#0; &#x1F; &#xD800; &#99999999999; &#X41; &#65; &#x1F600;</name>`,
+			expected: `<name>&amp;#0; &amp;#x1F; &amp;#xD800; &amp;#99999999999; &amp;#X41; &#65; &#x1F600;</name>`,
+		},
+		{
+			name:     "Ampersands in attributes are escaped",
+			input:    `<item name="Dungeons & Dragons" id="1"/>`,
+			expected: `<item name="Dungeons &amp; Dragons" id="1"/>`,
+		},
+		{
+			name:     "Invalid UTF-8 is replaced",
+			input:    "<name>bad\xff byte</name>",
+			expected: "<name>bad� byte</name>",
+		},
+		{
+			name:     "Comments and processing instructions are preserved",
+			input:    `<?xml version="1.0"?><!-- Q&A --><?app a&b?><item>Q&A</item>`,
+			expected: `<?xml version="1.0"?><!-- Q&A --><?app a&b?><item>Q&amp;A</item>`,
+		},
+		{
+			name:     "Trailing ampersand is escaped",
+			input:    "<name>end &",
+			expected: "<name>end &amp;",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := fixMalformedXML([]byte(tt.input))
+			require.Equal(t, tt.expected, string(result), "XML should be sanitised correctly")
+
+			chunked, err := io.ReadAll(newSanitiser(iotest.OneByteReader(strings.NewReader(tt.input))))
+			require.NoError(t, err, "Sanitiser should read one byte at a time")
+			require.Equal(t, tt.expected, string(chunked), "Sanitising should not depend on read sizes")
+		})
+	}
+}
+
+// xmlChars reports whether s is valid UTF-8 containing only characters XML allows
+func xmlChars(s string) bool {
+	for _, r := range s {
+		if r == utf8.RuneError || !isXMLChar(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// bggDocument builds a BGG-style document with text embedded in an attribute, element
+// text and a CDATA section. Only the characters that would make the document's
+// structure ambiguous are removed, as BGG doesn't return them unescaped.
+func bggDocument(text string) []byte {
+	attr := strings.NewReplacer("<", "", `"`, "").Replace(text)
+	body := strings.NewReplacer("<", "", "]]>", "").Replace(text)
+
+	return []byte(`<?xml version="1.0" encoding="utf-8"?>` +
+		`<items termsofuse="https://boardgamegeek.com/xmlapi/termsofuse">` +
+		`<item type="boardgame" id="13"><name type="primary" value="` + attr + `"/>` +
+		`<description>` + body + `</description>` +
+		`<comment><![CDATA[` + body + `]]></comment></item></items>`)
+}
+
+func FuzzSanitiser(f *testing.F) {
+	for _, seed := range []string{
+		"Dungeons & Dragons",
+		"Caf&eacute; &amp; &#169; &LT;b&GT;",
+		"Game\x0Bdescription\x1Fhere",
+		"&#0; &#xFFFE; &#x110000; &bogus; &",
+		"bad\xff\xfe utf-8",
+		"CDATA_PLACEHOLDER_0 ENTITY_PLACEHOLDERamp;",
+		"<![CDATA[nested",
+	} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, text string) {
+		sanitised := fixMalformedXML(bggDocument(text))
+		require.NoError(t, wellFormed(sanitised), "Sanitised XML should be well-formed: %q", sanitised)
+		require.Equal(t, sanitised, fixMalformedXML(sanitised), "Sanitising should be idempotent")
+	})
+}
+
+func FuzzSanitiser_ValidXMLUnchanged(f *testing.F) {
+	f.Add(`<items><item id="1" name="A &amp; B">Caf&#233; &lt;3</item><c><![CDATA[& <raw>]]></c></items>`)
+	f.Add(`<?xml version="1.0"?><!-- Q&A --><items/>`)
+
+	f.Fuzz(func(t *testing.T, input string) {
+		// encoding/xml doesn't check the characters in comments and processing instructions,
+		// and directives such as <!DOCTYPE> may legitimately contain bare ampersands
+		directives := strings.Count(input, "<!") - strings.Count(input, "<![CDATA[") - strings.Count(input, "<!--")
+		if wellFormed([]byte(input)) != nil || !xmlChars(input) || directives > 0 {
+			t.Skip()
+		}
+
+		require.Equal(t, input, string(fixMalformedXML([]byte(input))), "Well-formed XML should be unchanged")
+	})
+}
@@ -0,0 +1,157 @@
+package request
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/kkjdaniel/gogeek/v2"
+)
+
+// Stream performs a GET request against the BGG API and decodes the XML response
+// incrementally. It is equivalent to calling StreamContext with context.Background().
+func Stream(client *gogeek.Client, url string, root interface{}, item func(*xml.Decoder, xml.StartElement) error) error {
+	return StreamContext(context.Background(), client, url, root, item)
+}
+
+// StreamContext performs a GET request against the BGG API and decodes the XML
+// response incrementally with an xml.Decoder, so large responses can be processed
+// in bounded memory.
+//
+// If root is not nil, the attributes of the document's root element, which typically
+// hold totals and paging details, are unmarshalled into it; its child fields are left
+// empty. item is then called for each child of the root element, in order, with the
+// decoder positioned on it, and must consume the element, usually with d.DecodeElement.
+// If item returns an error, decoding stops and that error is returned; syntax errors
+// met while item decodes the element are reported as ErrXMLParseError.
+//
+// Requests are rate limited, polled while queued and retried in the same way as
+// FetchAndUnmarshalContext, and in-band <error> documents are reported the same way.
+// Streamed responses are neither served from nor stored in the client's cache, and
+// are not shared with concurrent identical requests.
+//
+// Example:
+//
+//	err := request.StreamContext(ctx, client, url, nil, func(d *xml.Decoder, start xml.StartElement) error {
+//	    var item collection.CollectionItem
+//	    if err := d.DecodeElement(&item, &start); err != nil {
+//	        return err
+//	    }
+//	    return process(item)
+//	})
+func StreamContext(ctx context.Context, client *gogeek.Client, url string, root interface{}, item func(*xml.Decoder, xml.StartElement) error) (err error) {
+	c := &call{url: url}
+	start := time.Now()
+	ctx, end := c.observe(ctx, client)
+	defer func() {
+		c.record(ctx)
+		c.logResult(ctx, client, start, err)
+		end(c.info(), err)
+	}()
+
+	resp, err := do(ctx, client, c)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	c.header = resp.Header
+
+	d := newStreamDecoder(resp.Body)
+
+	rootStart, err := nextStartElement(d)
+	if err != nil {
+		return c.streamError(ctx, err)
+	}
+
+	if rootStart.Name.Local == "error" || rootStart.Name.Local == "errors" {
+		var doc errorDocument
+		if err := d.DecodeElement(&doc, &rootStart); err != nil {
+			return c.streamError(ctx, err)
+		}
+		apiErr := c.apiError(classifyErrorMessage(doc.message()), nil, nil)
+		apiErr.Message = doc.message()
+		return apiErr
+	}
+
+	if root != nil {
+		if err := decodeAttributes(rootStart, root); err != nil {
+			return fmt.Errorf("%w: failed to unmarshal into %T: %v", ErrUnmarshalError, root, err)
+		}
+	}
+
+	for {
+		token, err := d.Token()
+		if err != nil {
+			return c.streamError(ctx, err)
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			if err := item(d, t); err != nil {
+				return c.itemError(ctx, err)
+			}
+		case xml.EndElement:
+			return nil
+		}
+	}
+}
+
+// newStreamDecoder returns a decoder for BGG responses that repairs malformed XML as it reads
+func newStreamDecoder(r io.Reader) *xml.Decoder {
+	return xml.NewDecoder(newSanitiser(r))
+}
+
+// nextStartElement advances d to the next start element
+func nextStartElement(d *xml.Decoder) (xml.StartElement, error) {
+	for {
+		token, err := d.Token()
+		if err != nil {
+			return xml.StartElement{}, err
+		}
+		if start, ok := token.(xml.StartElement); ok {
+			return start, nil
+		}
+	}
+}
+
+// decodeAttributes unmarshals the attributes of start into v, as if start were an empty element
+func decodeAttributes(start xml.StartElement, v interface{}) error {
+	var buf bytes.Buffer
+	e := xml.NewEncoder(&buf)
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+	if err := e.EncodeToken(start.End()); err != nil {
+		return err
+	}
+	if err := e.Flush(); err != nil {
+		return err
+	}
+	return xml.Unmarshal(buf.Bytes(), v)
+}
+
+// streamError reports a failure to read or decode a streamed response. The context's
+// error is returned if it ended while the body was being read.
+func (c *call) streamError(ctx context.Context, err error) error {
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return ctxErr
+	}
+	if errors.Is(err, io.EOF) {
+		return c.apiError(ErrEmptyResponse, nil, nil)
+	}
+	return fmt.Errorf("%w: %v", ErrXMLParseError, err)
+}
+
+// itemError reports an error returned by a StreamContext item callback. Syntax errors
+// and read failures from the decoder are reported as they would be outside the callback.
+func (c *call) itemError(ctx context.Context, err error) error {
+	var syntaxErr *xml.SyntaxError
+	if errors.As(err, &syntaxErr) || errors.Is(err, ctx.Err()) {
+		return c.streamError(ctx, err)
+	}
+	return err
+}
@@ -0,0 +1,124 @@
+package request
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/kkjdaniel/gogeek/v2"
+	"github.com/kkjdaniel/gogeek/v2/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+type streamedItem struct {
+	ID   int    `xml:"id,attr"`
+	Name string `xml:"name"`
+}
+
+type streamedRoot struct {
+	Total int            `xml:"total,attr"`
+	Items []streamedItem `xml:"item"`
+}
+
+// collectItems returns a StreamContext item callback that appends each <item> to items
+func collectItems(items *[]streamedItem) func(*xml.Decoder, xml.StartElement) error {
+	return func(d *xml.Decoder, start xml.StartElement) error {
+		var item streamedItem
+		if err := d.DecodeElement(&item, &start); err != nil {
+			return err
+		}
+		*items = append(*items, item)
+		return nil
+	}
+}
+
+func TestStream_Success(t *testing.T) {
+	defer testutils.ActivateMocks()()
+
+	testURL := "https://example.com/xmlapi2/collection?username=someone"
+	testutils.SetupSequentialResponders(t, testURL, []testutils.MockResponse{
+		{StatusCode: http.StatusAccepted, Body: ""},
+		{StatusCode: http.StatusOK, Body: "<?xml version=\"1.0\"?>\n<items total=\"2\">" +
+			"<item id=\"1\"><name>Dungeons & Dragons &ndash; Starter\x0B</name></item>" +
+			"<item id=\"2\"><name>Catan</name></item></items>"},
+	})
+
+	var root streamedRoot
+	var items []streamedItem
+	var info gogeek.ResponseInfo
+	client := gogeek.NewClient(gogeek.WithQueuePolicy(gogeek.QueuePolicy{MaxPolls: 1, InitialDelay: 10 * time.Millisecond}))
+	ctx := gogeek.ContextWithResponseInfo(context.Background(), &info)
+
+	err := StreamContext(ctx, client, testURL, &root, collectItems(&items))
+
+	require.NoError(t, err, "StreamContext should succeed")
+	require.Equal(t, streamedRoot{Total: 2}, root, "Root attributes should be decoded without children")
+	require.Equal(t, []streamedItem{
+		{ID: 1, Name: "Dungeons & Dragons – Starter"},
+		{ID: 2, Name: "Catan"},
+	}, items, "Items should be streamed in order despite malformed XML")
+	require.Equal(t, 2, info.Attempts, "Queued responses should be polled")
+}
+
+func TestStream_InBandError(t *testing.T) {
+	defer testutils.ActivateMocks()()
+
+	testURL := "https://example.com/xmlapi2/plays?username=nobody"
+	httpmock.RegisterResponder("GET", testURL, httpmock.NewStringResponder(http.StatusOK,
+		`<div class="messagebox error">Invalid object or user</div>`))
+
+	var items []streamedItem
+	err := Stream(gogeek.NewClient(), testURL, nil, collectItems(&items))
+	require.NoError(t, err, "Non-error documents should be streamed")
+
+	httpmock.RegisterResponder("GET", testURL, httpmock.NewStringResponder(http.StatusOK,
+		`<errors><error><message>Invalid username specified</message></error></errors>`))
+
+	err = Stream(gogeek.NewClient(), testURL, nil, collectItems(&items))
+	require.ErrorIs(t, err, ErrUserNotFound, "In-band errors should be classified")
+
+	var apiErr *gogeek.APIError
+	require.True(t, errors.As(err, &apiErr), "Error should be an APIError")
+	require.Equal(t, "Invalid username specified", apiErr.Message, "APIError should carry the BGG message")
+}
+
+func TestStream_CallbackError(t *testing.T) {
+	defer testutils.ActivateMocks()()
+
+	testURL := "https://example.com/xmlapi2/collection?username=someone"
+	httpmock.RegisterResponder("GET", testURL, httpmock.NewStringResponder(http.StatusOK,
+		`<items><item id="1"/><item id="2"/><item id="3"/></items>`))
+
+	stop := errors.New("stop")
+	calls := 0
+	err := Stream(gogeek.NewClient(), testURL, nil, func(d *xml.Decoder, start xml.StartElement) error {
+		calls++
+		if calls == 2 {
+			return stop
+		}
+		return d.Skip()
+	})
+
+	require.Equal(t, stop, err, "Callback errors should be returned unchanged")
+	require.Equal(t, 2, calls, "Decoding should stop after the callback fails")
+}
+
+func TestStream_TruncatedXML(t *testing.T) {
+	defer testutils.ActivateMocks()()
+
+	testURL := "https://example.com/xmlapi2/collection?username=someone"
+	httpmock.RegisterResponder("GET", testURL, httpmock.NewStringResponder(http.StatusOK,
+		`<items><item id="1"><name>Unclosed`))
+
+	var items []streamedItem
+	err := Stream(gogeek.NewClient(), testURL, nil, collectItems(&items))
+	require.ErrorIs(t, err, ErrXMLParseError, "Truncated XML should fail to parse")
+
+	httpmock.RegisterResponder("GET", testURL, httpmock.NewStringResponder(http.StatusOK, ""))
+	err = Stream(gogeek.NewClient(), testURL, nil, collectItems(&items))
+	require.ErrorIs(t, err, ErrEmptyResponse, "Empty bodies should be reported")
+}
@@ -0,0 +1,99 @@
+package gogeek
+
+import (
+	"math"
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+// RetryPolicy controls how requests that fail with a retryable HTTP status are retried.
+//
+// Delays grow exponentially from BaseDelay, doubling on each retry, and are capped at MaxDelay.
+// Queued (202) responses are not covered by the retry policy.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts, including the first request
+	// A value of 1 or less disables retries
+	MaxAttempts int
+	// BaseDelay is the delay before the first retry
+	BaseDelay time.Duration
+	// MaxDelay caps the delay between retries, including delays requested through Retry-After
+	MaxDelay time.Duration
+	// Jitter randomises each delay by up to this fraction of its value (e.g., 0.2 for ±20%)
+	Jitter float64
+	// RetryableStatuses lists the HTTP status codes that should be retried
+	RetryableStatuses []int
+	// RespectRetryAfter waits at least as long as the server's Retry-After header requests
+	RespectRetryAfter bool
+}
+
+// DefaultRetryPolicy returns the retry policy used by new clients
+// It retries rate limited (429) and transient server errors (500, 502, 503, 504) up to 4 attempts,
+// starting at 2 seconds and backing off to at most 30 seconds, honouring Retry-After
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 4,
+		BaseDelay:   2 * time.Second,
+		MaxDelay:    30 * time.Second,
+		Jitter:      0.2,
+		RetryableStatuses: []int{
+			http.StatusTooManyRequests,
+			http.StatusInternalServerError,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+		RespectRetryAfter: true,
+	}
+}
+
+// NoRetryPolicy returns a retry policy that never retries
+func NoRetryPolicy() RetryPolicy {
+	return RetryPolicy{MaxAttempts: 1}
+}
+
+// Retryable reports whether a response with the given status code should be retried
+func (p RetryPolicy) Retryable(statusCode int) bool {
+	for _, status := range p.RetryableStatuses {
+		if status == statusCode {
+			return true
+		}
+	}
+	return false
+}
+
+// Delay returns how long to wait before the given retry (0 for the first retry)
+// retryAfter is the delay requested by the server, or zero if none was given
+func (p RetryPolicy) Delay(retry int, retryAfter time.Duration) time.Duration {
+	delay := time.Duration(float64(p.BaseDelay) * math.Pow(2, float64(retry)))
+
+	if p.Jitter > 0 {
+		delay += time.Duration((rand.Float64()*2 - 1) * p.Jitter * float64(delay))
+	}
+
+	if p.RespectRetryAfter && retryAfter > delay {
+		delay = retryAfter
+	}
+
+	if p.MaxDelay > 0 && (delay > p.MaxDelay || delay < 0) {
+		delay = p.MaxDelay
+	}
+
+	if delay < 0 {
+		delay = 0
+	}
+
+	return delay
+}
+
+// RetryPolicy returns the retry policy for this client
+func (c *Client) RetryPolicy() RetryPolicy {
+	return c.retryPolicy
+}
+
+// WithRetryPolicy configures how the client retries rate limited and transient server errors
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return func(c *Client) {
+		c.retryPolicy = policy
+	}
+}
@@ -0,0 +1,54 @@
+package gogeek
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultRetryPolicy(t *testing.T) {
+	policy := NewClient().RetryPolicy()
+
+	require.Equal(t, DefaultRetryPolicy(), policy, "New clients should use the default retry policy")
+	require.True(t, policy.Retryable(http.StatusTooManyRequests), "429 should be retryable")
+	require.True(t, policy.Retryable(http.StatusServiceUnavailable), "503 should be retryable")
+	require.False(t, policy.Retryable(http.StatusNotFound), "404 should not be retryable")
+	require.False(t, policy.Retryable(http.StatusAccepted), "202 is handled by queue polling, not retries")
+}
+
+func TestRetryPolicy_Delay(t *testing.T) {
+	policy := RetryPolicy{
+		BaseDelay:         time.Second,
+		MaxDelay:          10 * time.Second,
+		RespectRetryAfter: true,
+	}
+
+	require.Equal(t, time.Second, policy.Delay(0, 0), "First retry should use the base delay")
+	require.Equal(t, 2*time.Second, policy.Delay(1, 0), "Delay should double on each retry")
+	require.Equal(t, 8*time.Second, policy.Delay(3, 0), "Delay should keep growing exponentially")
+	require.Equal(t, 10*time.Second, policy.Delay(10, 0), "Delay should be capped at MaxDelay")
+	require.Equal(t, 5*time.Second, policy.Delay(0, 5*time.Second), "Retry-After should be honoured")
+	require.Equal(t, 10*time.Second, policy.Delay(0, time.Minute), "Retry-After should be capped at MaxDelay")
+
+	policy.RespectRetryAfter = false
+	require.Equal(t, time.Second, policy.Delay(0, 5*time.Second), "Retry-After should be ignored when disabled")
+}
+
+func TestRetryPolicy_DelayJitter(t *testing.T) {
+	policy := RetryPolicy{BaseDelay: time.Second, Jitter: 0.5}
+
+	for i := 0; i < 100; i++ {
+		delay := policy.Delay(0, 0)
+		require.GreaterOrEqual(t, delay, 500*time.Millisecond, "Jitter should not reduce the delay by more than 50%")
+		require.LessOrEqual(t, delay, 1500*time.Millisecond, "Jitter should not increase the delay by more than 50%")
+	}
+}
+
+func TestWithRetryPolicy(t *testing.T) {
+	client := NewClient(WithRetryPolicy(NoRetryPolicy()))
+
+	require.Equal(t, 1, client.RetryPolicy().MaxAttempts, "Retry policy should be configurable")
+	require.False(t, client.RetryPolicy().Retryable(http.StatusTooManyRequests), "NoRetryPolicy should not retry")
+}
@@ -1,6 +1,7 @@
 package search
 
 import (
+	"context"
 	"net/url"
 
 	"github.com/kkjdaniel/gogeek/v2"
@@ -31,6 +32,12 @@ import (
 //	// Exact match search
 //	exactResults, err := search.Query(client, "catan", true)
 func Query(client *gogeek.Client, query string, exact ...bool) (*SearchResults, error) {
+	return QueryContext(context.Background(), client, query, exact...)
+}
+
+// QueryContext is like Query but carries a context that can cancel the request
+// while it waits on the rate limiter, is in flight, or is backing off between retries.
+func QueryContext(ctx context.Context, client *gogeek.Client, query string, exact ...bool) (*SearchResults, error) {
 	params := url.Values{}
 	params.Set("query", query)
 
@@ -38,10 +45,10 @@ func Query(client *gogeek.Client, query string, exact ...bool) (*SearchResults,
 		params.Set("exact", "1")
 	}
 
-	requestURL := constants.SearchEndpoint + "?" + params.Encode()
+	requestURL := client.Endpoint(constants.SearchPath) + "?" + params.Encode()
 
 	var searchResults SearchResults
-	if err := request.FetchAndUnmarshal(client, requestURL, &searchResults); err != nil {
+	if err := request.FetchAndUnmarshalContext(ctx, client, requestURL, &searchResults); err != nil {
 		return nil, err
 	}
 
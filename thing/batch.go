@@ -0,0 +1,159 @@
+package thing
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/kkjdaniel/gogeek/v2"
+)
+
+// MaxIDsPerQuery is the maximum number of IDs BGG accepts in a single thing request
+const MaxIDsPerQuery = 20
+
+// BatchConcurrency is the maximum number of batches QueryAll requests at once.
+// Requests still wait on the client's rate limiter.
+const BatchConcurrency = 4
+
+// BatchFailure describes a batch of IDs that QueryAll failed to retrieve
+type BatchFailure struct {
+	// IDs are the IDs requested in the batch
+	IDs []int
+	// Err is the error returned for the batch
+	Err error
+}
+
+// BatchError is returned by QueryAll when one or more batches fail. Items from the
+// batches that succeeded are still returned alongside it.
+type BatchError struct {
+	// Batches is the total number of batches requested
+	Batches int
+	// Failures lists the batches that failed, in input order
+	Failures []BatchFailure
+}
+
+func (e *BatchError) Error() string {
+	return fmt.Sprintf("%d of %d batches failed: %v", len(e.Failures), e.Batches, e.Failures[0].Err)
+}
+
+// Unwrap returns the error of every failed batch, so errors.Is and errors.As match any of them
+func (e *BatchError) Unwrap() []error {
+	errs := make([]error, len(e.Failures))
+	for i, failure := range e.Failures {
+		errs[i] = failure.Err
+	}
+	return errs
+}
+
+// FailedIDs returns the IDs of every failed batch, which can be passed to QueryAll to try again
+func (e *BatchError) FailedIDs() []int {
+	var ids []int
+	for _, failure := range e.Failures {
+		ids = append(ids, failure.IDs...)
+	}
+	return ids
+}
+
+// QueryAll retrieves any number of board games, splitting the IDs into batches of
+// MaxIDsPerQuery and requesting up to BatchConcurrency batches at once under the
+// client's rate limiter.
+//
+// Duplicate IDs are requested once, and the returned items are in the order their IDs
+// first appear in ids; IDs BGG doesn't recognise are omitted. If any batch fails, the
+// items from the other batches are returned along with a *BatchError describing each
+// failure. Options apply to every batch.
+//
+// Example:
+//
+//	client := gogeek.NewClient()
+//	details, err := thing.QueryAll(client, collectionIDs)
+//	var batchErr *thing.BatchError
+//	if errors.As(err, &batchErr) {
+//	    log.Printf("Failed to retrieve %d games: %v", len(batchErr.FailedIDs()), err)
+//	} else if err != nil {
+//	    log.Fatal(err)
+//	}
+func QueryAll(client *gogeek.Client, ids []int, opts ...ThingOption) (*Items, error) {
+	return QueryAllContext(context.Background(), client, ids, opts...)
+}
+
+// QueryAllContext is like QueryAll but carries a context that can cancel the requests
+// while they wait on the rate limiter, are in flight, or are backing off between retries.
+func QueryAllContext(ctx context.Context, client *gogeek.Client, ids []int, opts ...ThingOption) (*Items, error) {
+	if len(ids) == 0 {
+		return nil, ErrNoIDs
+	}
+
+	unique := dedupe(ids)
+	batches := chunk(unique, MaxIDsPerQuery)
+	results := make([]*Items, len(batches))
+	errs := make([]error, len(batches))
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, BatchConcurrency)
+	for i, batch := range batches {
+		wg.Add(1)
+		go func(i int, batch []int) {
+			defer wg.Done()
+
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				errs[i] = ctx.Err()
+				return
+			}
+
+			results[i], errs[i] = QueryContext(ctx, client, batch, opts...)
+		}(i, batch)
+	}
+	wg.Wait()
+
+	byID := make(map[int]Item, len(unique))
+	batchErr := &BatchError{Batches: len(batches)}
+	for i, result := range results {
+		if errs[i] != nil {
+			batchErr.Failures = append(batchErr.Failures, BatchFailure{IDs: batches[i], Err: errs[i]})
+			continue
+		}
+		for _, item := range result.Items {
+			byID[item.ID] = item
+		}
+	}
+
+	items := &Items{Items: make([]Item, 0, len(byID))}
+	for _, id := range unique {
+		if item, ok := byID[id]; ok {
+			items.Items = append(items.Items, item)
+		}
+	}
+
+	if len(batchErr.Failures) > 0 {
+		return items, batchErr
+	}
+
+	return items, nil
+}
+
+// dedupe returns ids without duplicates, keeping the first occurrence of each
+func dedupe(ids []int) []int {
+	seen := make(map[int]bool, len(ids))
+	unique := make([]int, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
+
+// chunk splits ids into consecutive batches of at most size IDs
+func chunk(ids []int, size int) [][]int {
+	var batches [][]int
+	for len(ids) > size {
+		batches = append(batches, ids[:size:size])
+		ids = ids[size:]
+	}
+	return append(batches, ids)
+}
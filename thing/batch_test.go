@@ -0,0 +1,155 @@
+package thing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/kkjdaniel/gogeek/v2"
+
+	"github.com/stretchr/testify/require"
+)
+
+// batchServer serves a minimal item for every requested ID, and a 400 for any batch containing failID
+func batchServer(t *testing.T, failID int) (*httptest.Server, *[][]int, *int) {
+	t.Helper()
+
+	var mu sync.Mutex
+	var batches [][]int
+	inFlight, maxInFlight := 0, 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		inFlight++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		mu.Unlock()
+		defer func() {
+			mu.Lock()
+			inFlight--
+			mu.Unlock()
+		}()
+
+		var ids []int
+		for _, value := range strings.Split(r.URL.Query().Get("id"), ",") {
+			id, err := strconv.Atoi(value)
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			ids = append(ids, id)
+		}
+
+		mu.Lock()
+		batches = append(batches, ids)
+		mu.Unlock()
+
+		var body strings.Builder
+		body.WriteString("<items>")
+		for _, id := range ids {
+			if id == failID {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			fmt.Fprintf(&body, `<item type="boardgame" id="%d"><name type="primary" value="Game %d"/></item>`, id, id)
+		}
+		body.WriteString("</items>")
+		w.Write([]byte(body.String()))
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &batches, &maxInFlight
+}
+
+func batchClient(server *httptest.Server) *gogeek.Client {
+	return gogeek.NewClient(
+		gogeek.WithBaseURL(server.URL+"/xmlapi2"),
+		gogeek.WithRateLimit(1000, 10),
+	)
+}
+
+func itemIDs(items *Items) []int {
+	ids := make([]int, len(items.Items))
+	for i, item := range items.Items {
+		ids[i] = item.ID
+	}
+	return ids
+}
+
+func TestQueryAll(t *testing.T) {
+	server, batches, maxInFlight := batchServer(t, 0)
+
+	var ids []int
+	for id := 200; id > 0; id-- {
+		ids = append(ids, id)
+	}
+	// Duplicates are requested once and keep their first position
+	ids = append(ids, 5, 150, 5)
+
+	items, err := QueryAll(batchClient(server), ids)
+
+	require.NoError(t, err, "QueryAll should not return an error")
+	require.Equal(t, ids[:200], itemIDs(items), "Items should be returned in input order without duplicates")
+	require.Len(t, *batches, 10, "IDs should be requested in batches of 20")
+	for _, batch := range *batches {
+		require.Len(t, batch, MaxIDsPerQuery, "Each batch should be full")
+	}
+	require.LessOrEqual(t, *maxInFlight, BatchConcurrency, "Concurrent batches should be bounded")
+}
+
+func TestQueryAll_PartialFailure(t *testing.T) {
+	server, _, _ := batchServer(t, 25)
+
+	ids := make([]int, 50)
+	for i := range ids {
+		ids[i] = i + 1
+	}
+
+	items, err := QueryAll(batchClient(server), ids)
+
+	var batchErr *BatchError
+	require.ErrorAs(t, err, &batchErr, "QueryAll should return a BatchError")
+	require.Equal(t, 3, batchErr.Batches)
+	require.Len(t, batchErr.Failures, 1, "Only the batch containing the failing ID should fail")
+	require.Equal(t, ids[20:40], batchErr.FailedIDs())
+
+	var apiErr *gogeek.APIError
+	require.ErrorAs(t, err, &apiErr, "The batch error should unwrap to the underlying error")
+	require.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+
+	require.Equal(t, append(ids[:20:20], ids[40:]...), itemIDs(items), "Items from successful batches should be kept")
+}
+
+func TestQueryAll_NoIDs(t *testing.T) {
+	items, err := QueryAll(gogeek.NewClient(), nil)
+
+	require.ErrorIs(t, err, ErrNoIDs)
+	require.Nil(t, items)
+}
+
+func TestQueryAllContext_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	server, batches, _ := batchServer(t, 0)
+	ids := make([]int, 45)
+	for i := range ids {
+		ids[i] = i + 1
+	}
+
+	items, err := QueryAllContext(ctx, batchClient(server), ids)
+
+	require.ErrorIs(t, err, context.Canceled, "QueryAllContext should return the context error")
+	var batchErr *BatchError
+	require.True(t, errors.As(err, &batchErr))
+	require.Len(t, batchErr.Failures, 3, "Every batch should fail")
+	require.Empty(t, items.Items)
+	require.Empty(t, *batches, "No requests should be sent")
+}
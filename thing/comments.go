@@ -0,0 +1,198 @@
+package thing
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/kkjdaniel/gogeek/v2"
+	"github.com/kkjdaniel/gogeek/v2/constants"
+	"github.com/kkjdaniel/gogeek/v2/request"
+)
+
+// MaxCommentPageSize is the largest page of comments BGG returns
+const MaxCommentPageSize = 100
+
+// Score returns the comment's rating, or false if the user hasn't rated the item
+func (c Comment) Score() (float64, bool) {
+	score, err := strconv.ParseFloat(c.Rating, 64)
+	if err != nil {
+		return 0, false
+	}
+	return score, true
+}
+
+// QueryComments retrieves a single page of comments for an item.
+//
+// Comments are requested by default; pass WithRatingComments to retrieve every rating
+// instead, with or without a comment. WithPage and WithPageSize select the page.
+//
+// Example:
+//
+//	client := gogeek.NewClient()
+//	page, err := thing.QueryComments(client, 13, thing.WithPage(2), thing.WithPageSize(100))
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Printf("Page %d of %d comments\n", page.Page, page.TotalItems)
+func QueryComments(client *gogeek.Client, id int, opts ...ThingOption) (*Comments, error) {
+	return QueryCommentsContext(context.Background(), client, id, opts...)
+}
+
+// QueryCommentsContext is like QueryComments but carries a context that can cancel the request
+// while it waits on the rate limiter, is in flight, or is backing off between retries.
+func QueryCommentsContext(ctx context.Context, client *gogeek.Client, id int, opts ...ThingOption) (*Comments, error) {
+	return queryComments(ctx, client, id, commentParams(opts))
+}
+
+// commentParams returns the query parameters for a page of comments with opts applied.
+// Statistics are left out unless requested, and comments are requested unless rating
+// comments are.
+func commentParams(opts []ThingOption) url.Values {
+	params := url.Values{}
+	for _, opt := range opts {
+		opt(params)
+	}
+	if params.Get("ratingcomments") == "" {
+		params.Set("comments", "1")
+	}
+	return params
+}
+
+func queryComments(ctx context.Context, client *gogeek.Client, id int, params url.Values) (*Comments, error) {
+	params.Set("id", strconv.Itoa(id))
+	url := client.Endpoint(constants.ThingPath) + "?" + params.Encode()
+
+	// Reuse the caller's ResponseInfo, if any, so it is still filled in
+	info := gogeek.ResponseInfoFromContext(ctx)
+	if info == nil {
+		info = &gogeek.ResponseInfo{}
+		ctx = gogeek.ContextWithResponseInfo(ctx, info)
+	}
+
+	var thing Items
+	if err := request.FetchAndUnmarshalContext(ctx, client, url, &thing); err != nil {
+		return nil, err
+	}
+
+	// BGG answers unknown IDs with an empty item list rather than an error document
+	if len(thing.Items) == 0 {
+		return nil, &gogeek.APIError{
+			Err:        request.ErrItemNotFound,
+			StatusCode: info.StatusCode,
+			URL:        info.URL,
+			Attempts:   info.Attempts,
+			Message:    fmt.Sprintf("item %d not found", id),
+		}
+	}
+	if thing.Items[0].Comments == nil {
+		return &Comments{}, nil
+	}
+
+	return thing.Items[0].Comments, nil
+}
+
+// CommentIterator walks every page of comments or ratings for an item. Each page is a
+// separate request that waits on the client's rate limiter.
+//
+// If a page fails, Next returns false and Err reports the error. The walk can be
+// resumed later by creating a new iterator with WithPage(it.NextPage()).
+type CommentIterator struct {
+	client   *gogeek.Client
+	id       int
+	params   url.Values
+	page     int
+	pageSize int
+	current  *Comments
+	err      error
+	done     bool
+}
+
+// NewCommentIterator returns an iterator over every page of comments for the item with the given ID.
+//
+// Comments are requested by default; pass WithRatingComments to walk every rating instead.
+// WithPage sets the page to start from, and WithPageSize the page size, which defaults to
+// MaxCommentPageSize.
+//
+// Example:
+//
+//	it := thing.NewCommentIterator(client, 13, thing.WithRatingComments())
+//	for it.Next(ctx) {
+//	    for _, comment := range it.Page().Comments {
+//	        if score, ok := comment.Score(); ok {
+//	            distribution[int(score)]++
+//	        }
+//	    }
+//	}
+//	if err := it.Err(); err != nil {
+//	    log.Printf("Stopped at page %d: %v", it.NextPage(), err)
+//	}
+func NewCommentIterator(client *gogeek.Client, id int, opts ...ThingOption) *CommentIterator {
+	params := commentParams(append([]ThingOption{WithPageSize(MaxCommentPageSize)}, opts...))
+
+	page, _ := strconv.Atoi(params.Get("page"))
+	if page < 1 {
+		page = 1
+	}
+	pageSize, _ := strconv.Atoi(params.Get("pagesize"))
+
+	return &CommentIterator{
+		client:   client,
+		id:       id,
+		params:   params,
+		page:     page,
+		pageSize: pageSize,
+	}
+}
+
+// Next fetches the next page of comments, returning false once every page has been
+// read, the context is done, or a request fails
+func (it *CommentIterator) Next(ctx context.Context) bool {
+	if it.done || it.err != nil {
+		return false
+	}
+
+	params := url.Values{}
+	for key, values := range it.params {
+		params[key] = values
+	}
+	params.Set("page", strconv.Itoa(it.page))
+
+	comments, err := queryComments(ctx, it.client, it.id, params)
+	if err != nil {
+		it.err = err
+		it.current = nil
+		return false
+	}
+
+	if len(comments.Comments) == 0 {
+		it.done = true
+		it.current = nil
+		return false
+	}
+
+	it.current = comments
+	it.page++
+	// Without a total, only a short page shows there are no more
+	if len(comments.Comments) < it.pageSize || (comments.TotalItems > 0 && (it.page-1)*it.pageSize >= comments.TotalItems) {
+		it.done = true
+	}
+	return true
+}
+
+// Page returns the page of comments fetched by the last successful call to Next
+func (it *CommentIterator) Page() *Comments {
+	return it.current
+}
+
+// NextPage returns the number of the next page to fetch, which can be passed to
+// WithPage to resume an interrupted walk
+func (it *CommentIterator) NextPage() int {
+	return it.page
+}
+
+// Err returns the error that stopped the iterator, if any
+func (it *CommentIterator) Err() error {
+	return it.err
+}
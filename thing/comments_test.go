@@ -0,0 +1,187 @@
+package thing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/kkjdaniel/gogeek/v2"
+	"github.com/kkjdaniel/gogeek/v2/request"
+
+	"github.com/stretchr/testify/require"
+)
+
+type countingLimiter struct {
+	waits atomic.Int32
+}
+
+func (l *countingLimiter) Wait(ctx context.Context) error {
+	l.waits.Add(1)
+	return ctx.Err()
+}
+
+// commentServer serves total rating comments for item 13, failing any page listed in failPages
+func commentServer(t *testing.T, total int, failPages ...int) (*httptest.Server, *[]string) {
+	t.Helper()
+
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		queries = append(queries, r.URL.RawQuery)
+
+		if query.Get("id") != "13" {
+			w.Write([]byte(`<items></items>`))
+			return
+		}
+
+		page, _ := strconv.Atoi(query.Get("page"))
+		pageSize, _ := strconv.Atoi(query.Get("pagesize"))
+		for _, fail := range failPages {
+			if page == fail {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+		}
+
+		var body strings.Builder
+		fmt.Fprintf(&body, `<items><item type="boardgame" id="13"><comments page="%d" totalitems="%d">`, page, total)
+		for i := (page - 1) * pageSize; i < page*pageSize && i < total; i++ {
+			rating := strconv.Itoa(i%10 + 1)
+			if i%4 == 3 {
+				rating = "N/A"
+			}
+			fmt.Fprintf(&body, `<comment username="user%d" rating="%s" value="" />`, i, rating)
+		}
+		body.WriteString(`</comments></item></items>`)
+		w.Write([]byte(body.String()))
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &queries
+}
+
+func collectComments(ctx context.Context, it *CommentIterator) []string {
+	var usernames []string
+	for it.Next(ctx) {
+		for _, comment := range it.Page().Comments {
+			usernames = append(usernames, comment.Username)
+		}
+	}
+	return usernames
+}
+
+func TestCommentIterator(t *testing.T) {
+	server, queries := commentServer(t, 25)
+	limiter := &countingLimiter{}
+	client := gogeek.NewClient(gogeek.WithBaseURL(server.URL+"/xmlapi2"), gogeek.WithRateLimiter(limiter))
+
+	it := NewCommentIterator(client, 13, WithRatingComments(), WithPageSize(10))
+	usernames := collectComments(context.Background(), it)
+
+	require.NoError(t, it.Err())
+	require.Len(t, usernames, 25, "Every comment should be returned")
+	require.Equal(t, "user0", usernames[0])
+	require.Equal(t, "user24", usernames[24])
+	require.Equal(t, []string{
+		"id=13&page=1&pagesize=10&ratingcomments=1",
+		"id=13&page=2&pagesize=10&ratingcomments=1",
+		"id=13&page=3&pagesize=10&ratingcomments=1",
+	}, *queries, "Pages should be requested in order without extra requests")
+	require.EqualValues(t, 3, limiter.waits.Load(), "Every page should wait on the rate limiter")
+	require.Equal(t, 4, it.NextPage())
+	require.False(t, it.Next(context.Background()), "Next should keep returning false once finished")
+}
+
+func TestCommentIterator_MissingTotal(t *testing.T) {
+	const total = 25
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+
+		var body strings.Builder
+		fmt.Fprintf(&body, `<items><item type="boardgame" id="13"><comments page="%d">`, page)
+		for i := (page - 1) * 10; i < page*10 && i < total; i++ {
+			fmt.Fprintf(&body, `<comment username="user%d" rating="7" value="" />`, i)
+		}
+		body.WriteString(`</comments></item></items>`)
+		w.Write([]byte(body.String()))
+	}))
+	defer server.Close()
+
+	client := gogeek.NewClient(gogeek.WithBaseURL(server.URL+"/xmlapi2"), gogeek.WithRateLimit(1000, 10))
+	it := NewCommentIterator(client, 13, WithPageSize(10))
+	usernames := collectComments(context.Background(), it)
+
+	require.NoError(t, it.Err())
+	require.Len(t, usernames, total, "Pages should be read until a short page when totalitems is missing")
+	require.Equal(t, 4, it.NextPage())
+}
+
+func TestCommentIterator_Resume(t *testing.T) {
+	server, _ := commentServer(t, 35, 3)
+	client := gogeek.NewClient(gogeek.WithBaseURL(server.URL+"/xmlapi2"), gogeek.WithRateLimit(1000, 10))
+
+	it := NewCommentIterator(client, 13, WithPageSize(10))
+	usernames := collectComments(context.Background(), it)
+
+	require.Error(t, it.Err(), "The failed page should stop the iterator")
+	require.Len(t, usernames, 20, "Pages before the failure should be returned")
+	require.Equal(t, 3, it.NextPage(), "The failed page should be the one to resume from")
+
+	server, queries := commentServer(t, 35)
+	client = gogeek.NewClient(gogeek.WithBaseURL(server.URL+"/xmlapi2"), gogeek.WithRateLimit(1000, 10))
+
+	it = NewCommentIterator(client, 13, WithPageSize(10), WithPage(it.NextPage()))
+	usernames = collectComments(context.Background(), it)
+
+	require.NoError(t, it.Err())
+	require.Len(t, usernames, 15, "The remaining comments should be returned")
+	require.Equal(t, "user20", usernames[0])
+	require.Equal(t, "comments=1&id=13&page=3&pagesize=10", (*queries)[0], "Comments should be requested by default")
+}
+
+func TestCommentIterator_Cancelled(t *testing.T) {
+	server, queries := commentServer(t, 25)
+	client := gogeek.NewClient(gogeek.WithBaseURL(server.URL + "/xmlapi2"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	it := NewCommentIterator(client, 13)
+	require.False(t, it.Next(ctx))
+	require.ErrorIs(t, it.Err(), context.Canceled)
+	require.Equal(t, 1, it.NextPage())
+	require.Empty(t, *queries, "No requests should be sent")
+}
+
+func TestQueryComments(t *testing.T) {
+	server, queries := commentServer(t, 25)
+	client := gogeek.NewClient(gogeek.WithBaseURL(server.URL+"/xmlapi2"), gogeek.WithRateLimit(1000, 10))
+
+	page, err := QueryComments(client, 13, WithPage(3), WithPageSize(10))
+	require.NoError(t, err)
+	require.Equal(t, 3, page.Page)
+	require.Equal(t, 25, page.TotalItems)
+	require.Len(t, page.Comments, 5)
+	require.Equal(t, "comments=1&id=13&page=3&pagesize=10", (*queries)[0])
+
+	score, ok := page.Comments[0].Score()
+	require.True(t, ok)
+	require.Equal(t, 1.0, score)
+	_, ok = page.Comments[3].Score()
+	require.False(t, ok, "N/A ratings should have no score")
+
+	_, err = QueryComments(client, 99)
+	require.True(t, errors.Is(err, request.ErrItemNotFound), "An unknown item should return ErrItemNotFound")
+
+	var apiErr *gogeek.APIError
+	require.ErrorAs(t, err, &apiErr, "An unknown item should return an APIError")
+	require.Equal(t, 1, apiErr.Attempts)
+	require.Equal(t, server.URL+"/xmlapi2/thing?comments=1&id=99", apiErr.URL)
+	require.Equal(t, "item 99 not found", apiErr.Message)
+}
@@ -0,0 +1,47 @@
+package thing
+
+import "encoding/xml"
+
+// History is a page of historical ratings, requested with WithHistorical
+type History struct {
+	// Page is the page of history returned, selected with WithPage
+	Page int
+	// Ratings holds a snapshot of the item's ratings for each date, in the order BGG returns them
+	Ratings []Statistics
+}
+
+// UnmarshalXML decodes an item, collecting the dated ratings snapshots BGG returns
+// for historical queries into History. Statistics holds the last set of ratings.
+func (i *Item) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	// item has Item's fields without this method; the statistics field below is shallower
+	// than item's statistics>ratings field, so it takes precedence
+	type item Item
+	var decoded struct {
+		item
+		Statistics *struct {
+			Page    int          `xml:"page,attr"`
+			Ratings []Statistics `xml:"ratings"`
+		} `xml:"statistics"`
+	}
+	if err := d.DecodeElement(&decoded, &start); err != nil {
+		return err
+	}
+
+	*i = Item(decoded.item)
+	if decoded.Statistics == nil || len(decoded.Statistics.Ratings) == 0 {
+		return nil
+	}
+
+	ratings := decoded.Statistics.Ratings
+	latest := ratings[len(ratings)-1]
+	i.Statistics = &latest
+
+	for _, r := range ratings {
+		if r.Date != "" {
+			i.History = &History{Page: decoded.Statistics.Page, Ratings: ratings}
+			break
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,128 @@
+package thing
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Listing is a copy of an item offered for sale on the BGG marketplace.
+//
+// The list date and price are kept as BGG sent them, so one listing in an unexpected
+// format doesn't fail the whole query; ListedAt and Price.Amount parse them.
+type Listing struct {
+	ListDate  StringValue    `xml:"listdate"`
+	Price     Price          `xml:"price"`
+	Condition ConditionValue `xml:"condition"`
+	Notes     StringValue    `xml:"notes"`
+	Link      ListingLink    `xml:"link"`
+}
+
+// ListedAt parses the listing's list date, returning the zero time if it is empty
+func (l Listing) ListedAt() (time.Time, error) {
+	value := strings.TrimSpace(l.ListDate.Value)
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	for _, layout := range listDateLayouts {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return parsed, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q", value)
+}
+
+type ListingLink struct {
+	Href  string `xml:"href,attr"`
+	Title string `xml:"title,attr"`
+}
+
+// Price is an amount of money in the currency given by its ISO 4217 code (e.g., "USD")
+type Price struct {
+	Currency string `xml:"currency,attr"`
+	Value    string `xml:"value,attr"`
+}
+
+// Amount parses the price as an exact decimal, returning zero if it is empty
+func (p Price) Amount() (Decimal, error) {
+	value := strings.TrimSpace(p.Value)
+	if value == "" {
+		return Decimal{}, nil
+	}
+	return ParseDecimal(value)
+}
+
+// Condition is the condition of a marketplace listing
+type Condition string
+
+const (
+	ConditionNew        Condition = "new"
+	ConditionLikeNew    Condition = "likenew"
+	ConditionVeryGood   Condition = "verygood"
+	ConditionGood       Condition = "good"
+	ConditionAcceptable Condition = "acceptable"
+)
+
+// Valid reports whether c is one of the conditions BGG documents
+func (c Condition) Valid() bool {
+	switch c {
+	case ConditionNew, ConditionLikeNew, ConditionVeryGood, ConditionGood, ConditionAcceptable:
+		return true
+	}
+	return false
+}
+
+type ConditionValue struct {
+	Value Condition `xml:"value,attr"`
+}
+
+// Decimal is an exact decimal number equal to Unscaled × 10^-Scale, so prices keep
+// the precision BGG sent them with (e.g., "25.00" is {Unscaled: 2500, Scale: 2})
+type Decimal struct {
+	Unscaled int64
+	Scale    int
+}
+
+// ParseDecimal parses a decimal number such as "25", "-3.5" or "1499.99"
+func ParseDecimal(s string) (Decimal, error) {
+	whole, frac, _ := strings.Cut(s, ".")
+	digits := strings.TrimLeft(whole, "+-")
+	if digits == "" && frac == "" || strings.ContainsAny(frac, "+-") {
+		return Decimal{}, fmt.Errorf("invalid decimal %q", s)
+	}
+
+	unscaled, err := strconv.ParseInt(whole+frac, 10, 64)
+	if err != nil {
+		return Decimal{}, fmt.Errorf("invalid decimal %q", s)
+	}
+
+	return Decimal{Unscaled: unscaled, Scale: len(frac)}, nil
+}
+
+// String formats d with its scale, e.g. "25.00"
+func (d Decimal) String() string {
+	s := strconv.FormatInt(d.Unscaled, 10)
+	if d.Scale <= 0 {
+		return s
+	}
+
+	sign := ""
+	if d.Unscaled < 0 {
+		sign, s = "-", s[1:]
+	}
+	if len(s) <= d.Scale {
+		s = strings.Repeat("0", d.Scale-len(s)+1) + s
+	}
+	return sign + s[:len(s)-d.Scale] + "." + s[len(s)-d.Scale:]
+}
+
+// Float64 returns d as a float64, which may lose precision
+func (d Decimal) Float64() float64 {
+	return float64(d.Unscaled) / math.Pow10(d.Scale)
+}
+
+// listDateLayouts are the formats BGG uses for marketplace list dates
+var listDateLayouts = []string{time.RFC1123Z, time.RFC1123, time.RFC3339}
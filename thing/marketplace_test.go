@@ -0,0 +1,69 @@
+package thing
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDecimal(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Decimal
+		str      string
+	}{
+		{"25.00", Decimal{Unscaled: 2500, Scale: 2}, "25.00"},
+		{"1499.5", Decimal{Unscaled: 14995, Scale: 1}, "1499.5"},
+		{"7", Decimal{Unscaled: 7, Scale: 0}, "7"},
+		{"0.05", Decimal{Unscaled: 5, Scale: 2}, "0.05"},
+		{".5", Decimal{Unscaled: 5, Scale: 1}, "0.5"},
+		{"-3.25", Decimal{Unscaled: -325, Scale: 2}, "-3.25"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			d, err := ParseDecimal(tt.input)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, d)
+			require.Equal(t, tt.str, d.String())
+		})
+	}
+
+	for _, input := range []string{"", ".", "abc", "1.2.3", "1.-5", "12,50", "99999999999999999999"} {
+		_, err := ParseDecimal(input)
+		require.Error(t, err, "ParseDecimal(%q) should fail", input)
+	}
+}
+
+func TestDecimal_Float64(t *testing.T) {
+	require.InDelta(t, 25.0, Decimal{Unscaled: 2500, Scale: 2}.Float64(), 1e-9)
+	require.InDelta(t, -0.05, Decimal{Unscaled: -5, Scale: 2}.Float64(), 1e-9)
+}
+
+func TestCondition_Valid(t *testing.T) {
+	require.True(t, ConditionVeryGood.Valid())
+	require.False(t, Condition("mint").Valid())
+}
+
+func TestListing_Invalid(t *testing.T) {
+	var listing Listing
+	err := xml.Unmarshal([]byte(`<listing><listdate value="yesterday"/><price currency="USD" value="free"/><condition value="new"/></listing>`), &listing)
+	require.NoError(t, err, "Unexpected formats should not fail the decode")
+	require.Equal(t, ConditionNew, listing.Condition.Value, "The rest of the listing should still decode")
+
+	_, err = listing.ListedAt()
+	require.Error(t, err, "An invalid list date should fail to parse")
+	_, err = listing.Price.Amount()
+	require.Error(t, err, "An invalid price should fail to parse")
+
+	err = xml.Unmarshal([]byte(`<listing><listdate value=""/><price currency="" value=""/></listing>`), &listing)
+	require.NoError(t, err)
+
+	listedAt, err := listing.ListedAt()
+	require.NoError(t, err, "An empty list date should parse as zero")
+	require.True(t, listedAt.IsZero())
+	amount, err := listing.Price.Amount()
+	require.NoError(t, err, "An empty price should parse as zero")
+	require.Equal(t, Decimal{}, amount)
+}
@@ -20,8 +20,13 @@ type Item struct {
 	Image         string        `xml:"image"`
 	Links         []Link        `xml:"link"`
 	Statistics    *Statistics   `xml:"statistics>ratings"`
+	History       *History      `xml:"-"`
 	Polls         []Poll        `xml:"poll"`
 	PollSummaries []PollSummary `xml:"poll-summary"`
+	Versions      []Version     `xml:"versions>item"`
+	Videos        *Videos       `xml:"videos"`
+	Marketplace   []Listing     `xml:"marketplacelistings>listing"`
+	Comments      *Comments     `xml:"comments"`
 }
 
 type Name struct {
@@ -43,12 +48,14 @@ type StringValue struct {
 }
 
 type Link struct {
-	Type  string `xml:"type,attr"`
-	ID    int    `xml:"id,attr"`
-	Value string `xml:"value,attr"`
+	Type    string `xml:"type,attr"`
+	ID      int    `xml:"id,attr"`
+	Value   string `xml:"value,attr"`
+	Inbound bool   `xml:"inbound,attr"`
 }
 
 type Statistics struct {
+	Date          string     `xml:"date,attr"`
 	UsersRated    IntValue   `xml:"usersrated"`
 	Average       FloatValue `xml:"average"`
 	BayesAverage  FloatValue `xml:"bayesaverage"`
@@ -100,3 +107,51 @@ type SummaryItem struct {
 	Name  string `xml:"name,attr"`
 	Value string `xml:"value,attr"`
 }
+
+// Version is a published edition of an item, such as a language edition or reprint.
+// Its links name the publishers, artists and languages of the edition, with an inbound
+// boardgameversion link back to the item itself.
+type Version struct {
+	Type          string      `xml:"type,attr"`
+	ID            int         `xml:"id,attr"`
+	Thumbnail     string      `xml:"thumbnail"`
+	Image         string      `xml:"image"`
+	Name          []Name      `xml:"name"`
+	Links         []Link      `xml:"link"`
+	YearPublished IntValue    `xml:"yearpublished"`
+	ProductCode   StringValue `xml:"productcode"`
+	// Width, Length and Depth are the box dimensions in inches, or 0 if unknown
+	Width  FloatValue `xml:"width"`
+	Length FloatValue `xml:"length"`
+	Depth  FloatValue `xml:"depth"`
+	// Weight is the weight in pounds, or 0 if unknown
+	Weight FloatValue `xml:"weight"`
+}
+
+type Videos struct {
+	Total  int     `xml:"total,attr"`
+	Videos []Video `xml:"video"`
+}
+
+type Video struct {
+	ID       int    `xml:"id,attr"`
+	Title    string `xml:"title,attr"`
+	Category string `xml:"category,attr"`
+	Language string `xml:"language,attr"`
+	Link     string `xml:"link,attr"`
+	Username string `xml:"username,attr"`
+	UserID   int    `xml:"userid,attr"`
+	PostDate string `xml:"postdate,attr"`
+}
+
+type Comments struct {
+	Page       int       `xml:"page,attr"`
+	TotalItems int       `xml:"totalitems,attr"`
+	Comments   []Comment `xml:"comment"`
+}
+
+type Comment struct {
+	Username string `xml:"username,attr"`
+	Rating   string `xml:"rating,attr"`
+	Value    string `xml:"value,attr"`
+}
@@ -1,7 +1,10 @@
 package thing
 
 import (
+	"context"
 	"fmt"
+	"net/url"
+	"strconv"
 	"strings"
 
 	"github.com/kkjdaniel/gogeek/v2"
@@ -16,6 +19,9 @@ var (
 	ErrNoIDs = fmt.Errorf("no IDs provided")
 )
 
+// ThingOption represents an option for customising thing queries
+type ThingOption func(params url.Values)
+
 // Query retrieves detailed information about one or more board games from the BoardGameGeek API.
 //
 // The function accepts a slice of BGG item IDs and returns a structured representation
@@ -25,6 +31,7 @@ var (
 // Parameters:
 //   - client: A GoGeek client configured with optional authentication
 //   - ids: A slice of integer IDs corresponding to board game entries in the BGG database
+//   - opts: Optional parameters selecting which sections to include; statistics are included by default
 //
 // Returns:
 //   - *Items: A pointer to an Items struct containing the detailed information for the requested games
@@ -38,27 +45,127 @@ var (
 //	    log.Fatalf("Failed to get game details: %v", err)
 //	}
 //	fmt.Printf("Retrieved details for %d games\n", len(details.Items))
-func Query(client *gogeek.Client, ids []int) (*Items, error) {
+//
+//	withVideos, err := thing.Query(client, []int{174430}, thing.WithVideos(), thing.WithComments())
+func Query(client *gogeek.Client, ids []int, opts ...ThingOption) (*Items, error) {
+	return QueryContext(context.Background(), client, ids, opts...)
+}
+
+// QueryContext is like Query but carries a context that can cancel the request
+// while it waits on the rate limiter, is in flight, or is backing off between retries.
+func QueryContext(ctx context.Context, client *gogeek.Client, ids []int, opts ...ThingOption) (*Items, error) {
 	if len(ids) == 0 {
 		return nil, ErrNoIDs
 	}
 
-	if len(ids) > 20 {
+	if len(ids) > MaxIDsPerQuery {
 		return nil, ErrTooManyIDs
 	}
 
+	var thing Items
+	if err := request.FetchAndUnmarshalContext(ctx, client, queryURL(client, ids, opts), &thing); err != nil {
+		return nil, err
+	}
+
+	return &thing, nil
+}
+
+// queryURL builds the thing request URL for ids with the given options applied
+func queryURL(client *gogeek.Client, ids []int, opts []ThingOption) string {
 	idStrings := make([]string, len(ids))
 	for i, id := range ids {
-		idStrings[i] = fmt.Sprintf("%d", id)
+		idStrings[i] = strconv.Itoa(id)
 	}
-	idParam := strings.Join(idStrings, ",")
 
-	url := fmt.Sprintf("%s?id=%s&stats=1", constants.ThingEndpoint, idParam)
+	params := url.Values{}
+	params.Set("id", strings.Join(idStrings, ","))
+	params.Set("stats", "1")
 
-	var thing Items
-	if err := request.FetchAndUnmarshal(client, url, &thing); err != nil {
-		return nil, err
+	for _, opt := range opts {
+		opt(params)
 	}
 
-	return &thing, nil
+	return client.Endpoint(constants.ThingPath) + "?" + params.Encode()
+}
+
+// WithStats sets whether ranking and rating statistics are included, which they are by default
+func WithStats(stats bool) ThingOption {
+	return func(params url.Values) {
+		if stats {
+			params.Set("stats", "1")
+		} else {
+			params.Del("stats")
+		}
+	}
+}
+
+// WithVersions includes the versions (editions) of each item
+func WithVersions() ThingOption {
+	return func(params url.Values) {
+		params.Set("versions", "1")
+	}
+}
+
+// WithVideos includes the videos linked to each item
+func WithVideos() ThingOption {
+	return func(params url.Values) {
+		params.Set("videos", "1")
+	}
+}
+
+// WithMarketplace includes the marketplace listings for each item
+func WithMarketplace() ThingOption {
+	return func(params url.Values) {
+		params.Set("marketplace", "1")
+	}
+}
+
+// WithComments includes a page of user comments for each item
+// Use WithPage and WithPageSize to select the page
+func WithComments() ThingOption {
+	return func(params url.Values) {
+		params.Set("comments", "1")
+	}
+}
+
+// WithRatingComments includes a page of user ratings for each item, with or without a comment
+// BGG ignores WithComments when rating comments are requested
+func WithRatingComments() ThingOption {
+	return func(params url.Values) {
+		params.Set("ratingcomments", "1")
+	}
+}
+
+// WithPage selects the page of comments to include, starting at 1
+func WithPage(page int) ThingOption {
+	return func(params url.Values) {
+		if page >= 1 {
+			params.Set("page", strconv.Itoa(page))
+		}
+	}
+}
+
+// WithPageSize sets the number of comments per page
+// Valid values: 10-100
+func WithPageSize(size int) ThingOption {
+	return func(params url.Values) {
+		if size >= 10 && size <= 100 {
+			params.Set("pagesize", strconv.Itoa(size))
+		}
+	}
+}
+
+// WithHistorical requests dated snapshots of each item's ratings, returned in History and paged with WithPage
+func WithHistorical() ThingOption {
+	return func(params url.Values) {
+		params.Set("historical", "1")
+	}
+}
+
+// WithTypes restricts results to items of the given types
+// Valid values: boardgame, boardgameexpansion, boardgameaccessory, videogame, rpgitem, rpgissue
+func WithTypes(types ...string) ThingOption {
+	return func(params url.Values) {
+		params.Set("type", strings.Join(types, ","))
+	}
 }
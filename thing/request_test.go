@@ -1,7 +1,12 @@
 package thing
 
 import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"testing"
+	"time"
 
 	"github.com/kkjdaniel/gogeek/v2"
 	"github.com/kkjdaniel/gogeek/v2/constants"
@@ -194,3 +199,271 @@ func TestQuery_Error(t *testing.T) {
 
 	testutils.TestRequestError(t, testURL, queryWrapper)
 }
+
+func TestQueryContext_ResponseInfo(t *testing.T) {
+	defer testutils.ActivateMocks()()
+
+	url := constants.ThingEndpoint + "?id=9&stats=1"
+	testutils.SetupMockResponder(t, url, mockDataFileValid)
+
+	var info gogeek.ResponseInfo
+	ctx := gogeek.ContextWithResponseInfo(context.Background(), &info)
+
+	client := gogeek.NewClient()
+	result, err := QueryContext(ctx, client, []int{9})
+
+	require.NoError(t, err, "QueryContext should not return an error")
+	require.NotNil(t, result, "Result should not be nil")
+	require.Equal(t, url, info.URL, "QueryContext should request the built URL with the caller's context")
+}
+
+func TestQuery_WithBaseURL(t *testing.T) {
+	mockData := testutils.LoadTestData(t, mockDataFileValid)
+
+	var requestedURI string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestedURI = r.URL.RequestURI()
+		w.Write(mockData)
+	}))
+	defer server.Close()
+
+	client := gogeek.NewClient(gogeek.WithBaseURL(server.URL + "/xmlapi2"))
+	thing, err := Query(client, []int{9})
+
+	require.NoError(t, err, "Query should not return an error")
+	require.Equal(t, "/xmlapi2/thing?id=9&stats=1", requestedURI, "Request should be sent to the configured base URL")
+	require.Len(t, thing.Items, 1, "Should decode the response from the configured base URL")
+}
+
+func TestQuery_WithOptions(t *testing.T) {
+	mockData := testutils.LoadTestData(t, "testdata/valid_thing_videos_comments_response.xml")
+
+	var query url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		w.Write(mockData)
+	}))
+	defer server.Close()
+
+	client := gogeek.NewClient(gogeek.WithBaseURL(server.URL + "/xmlapi2"))
+	thing, err := Query(client, []int{9, 13},
+		WithVideos(),
+		WithComments(),
+		WithPage(2),
+		WithPageSize(25),
+		WithTypes("boardgame", "boardgameexpansion"),
+	)
+	require.NoError(t, err, "Query should not return an error")
+
+	require.Equal(t, url.Values{
+		"id":       {"9,13"},
+		"stats":    {"1"},
+		"videos":   {"1"},
+		"comments": {"1"},
+		"page":     {"2"},
+		"pagesize": {"25"},
+		"type":     {"boardgame,boardgameexpansion"},
+	}, query, "Options should be sent as query parameters")
+
+	expected := &Items{
+		Items: []Item{
+			{
+				Type: "boardgame",
+				ID:   9,
+				Name: []Name{{Type: "primary", SortIndex: 1, Value: "Example Game"}},
+				Videos: &Videos{
+					Total: 2,
+					Videos: []Video{
+						{ID: 101, Title: "How to Play Example Game", Category: "instructional", Language: "English", Link: "https://www.youtube.com/watch?v=example1", Username: "reviewer", UserID: 501, PostDate: "2019-06-10T15:14:38-05:00"},
+						{ID: 102, Title: "Example Game Review", Category: "review", Language: "German", Link: "https://www.youtube.com/watch?v=example2", Username: "kritiker", UserID: 502, PostDate: "2020-01-02T08:00:00-06:00"},
+					},
+				},
+				Comments: &Comments{
+					Page:       2,
+					TotalItems: 718,
+					Comments: []Comment{
+						{Username: "alice", Rating: "8", Value: "Great with three players."},
+						{Username: "bob", Rating: "N/A", Value: "Looking forward to trying it."},
+						{Username: "carol", Rating: "6.5", Value: ""},
+					},
+				},
+			},
+		},
+	}
+
+	if diff := cmp.Diff(expected, thing); diff != "" {
+		t.Errorf("Thing mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestQueryURL_Options(t *testing.T) {
+	client := gogeek.NewClient()
+
+	tests := []struct {
+		name     string
+		opts     []ThingOption
+		expected string
+	}{
+		{"default", nil, "id=9&stats=1"},
+		{"without stats", []ThingOption{WithStats(false)}, "id=9"},
+		{"versions and marketplace", []ThingOption{WithVersions(), WithMarketplace()}, "id=9&marketplace=1&stats=1&versions=1"},
+		{"rating comments", []ThingOption{WithRatingComments()}, "id=9&ratingcomments=1&stats=1"},
+		{"historical", []ThingOption{WithHistorical(), WithPage(2)}, "historical=1&id=9&page=2&stats=1"},
+		{"invalid page and page size", []ThingOption{WithPage(0), WithPageSize(500)}, "id=9&stats=1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, constants.ThingEndpoint+"?"+tt.expected, queryURL(client, []int{9}, tt.opts))
+		})
+	}
+}
+
+func TestQuery_WithVersions(t *testing.T) {
+	defer testutils.ActivateMocks()()
+
+	url := constants.ThingEndpoint + "?id=9&stats=1&versions=1"
+	testutils.SetupMockResponder(t, url, "testdata/valid_thing_versions_response.xml")
+
+	client := gogeek.NewClient()
+	thing, err := Query(client, []int{9}, WithVersions())
+	require.NoError(t, err, "Query should not return an error")
+
+	expected := &Items{
+		Items: []Item{
+			{
+				Type:  "boardgame",
+				ID:    9,
+				Name:  []Name{{Type: "primary", SortIndex: 1, Value: "Example Game"}},
+				Links: []Link{{Type: "boardgameexpansion", ID: 10, Value: "Example Game: Expansion"}},
+				Versions: []Version{
+					{
+						Type:      "boardgameversion",
+						ID:        7001,
+						Thumbnail: "https://example.com/images/version_thumbnail.jpg",
+						Image:     "https://example.com/images/version_full.jpg",
+						Name:      []Name{{Type: "primary", SortIndex: 1, Value: "English first edition"}},
+						Links: []Link{
+							{Type: "boardgameversion", ID: 9, Value: "Example Game", Inbound: true},
+							{Type: "boardgamepublisher", ID: 6001, Value: "Publisher One"},
+							{Type: "boardgameartist", ID: 5001, Value: "Artist Name"},
+							{Type: "language", ID: 2184, Value: "English"},
+						},
+						YearPublished: IntValue{Value: 2000},
+						ProductCode:   StringValue{Value: "EX-001"},
+						Width:         FloatValue{Value: 11.75},
+						Length:        FloatValue{Value: 11.75},
+						Depth:         FloatValue{Value: 2.95},
+						Weight:        FloatValue{Value: 2.7},
+					},
+					{
+						Type: "boardgameversion",
+						ID:   7002,
+						Name: []Name{{Type: "primary", SortIndex: 1, Value: "German edition"}},
+						Links: []Link{
+							{Type: "boardgameversion", ID: 9, Value: "Example Game", Inbound: true},
+							{Type: "language", ID: 2188, Value: "German"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	if diff := cmp.Diff(expected, thing); diff != "" {
+		t.Errorf("Thing mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestQuery_WithMarketplace(t *testing.T) {
+	defer testutils.ActivateMocks()()
+
+	url := constants.ThingEndpoint + "?id=9&marketplace=1&stats=1"
+	testutils.SetupMockResponder(t, url, "testdata/valid_thing_marketplace_response.xml")
+
+	client := gogeek.NewClient()
+	thing, err := Query(client, []int{9}, WithMarketplace())
+	require.NoError(t, err, "Query should not return an error")
+
+	expected := &Items{
+		Items: []Item{
+			{
+				Type: "boardgame",
+				ID:   9,
+				Name: []Name{{Type: "primary", SortIndex: 1, Value: "Example Game"}},
+				Marketplace: []Listing{
+					{
+						ListDate:  StringValue{Value: "Sat, 18 Apr 2015 06:09:28 +0000"},
+						Price:     Price{Currency: "USD", Value: "25.00"},
+						Condition: ConditionValue{Value: ConditionLikeNew},
+						Notes:     StringValue{Value: "Played once, cards sleeved & sorted."},
+						Link:      ListingLink{Href: "https://boardgamegeek.com/market/product/1001", Title: "marketplace"},
+					},
+					{
+						ListDate:  StringValue{Value: "Tue, 02 Jan 2024 18:30:00 -0500"},
+						Price:     Price{Currency: "EUR", Value: "1499.5"},
+						Condition: ConditionValue{Value: ConditionNew},
+						Link:      ListingLink{Href: "https://boardgamegeek.com/market/product/1002", Title: "marketplace"},
+					},
+				},
+			},
+		},
+	}
+
+	if diff := cmp.Diff(expected, thing); diff != "" {
+		t.Errorf("Thing mismatch (-want +got):\n%s", diff)
+	}
+
+	listing := thing.Items[0].Marketplace[1]
+	listedAt, err := listing.ListedAt()
+	require.NoError(t, err, "The list date should parse")
+	require.True(t, time.Date(2024, time.January, 2, 23, 30, 0, 0, time.UTC).Equal(listedAt))
+
+	amount, err := listing.Price.Amount()
+	require.NoError(t, err, "The price should parse")
+	require.Equal(t, Decimal{Unscaled: 14995, Scale: 1}, amount)
+}
+
+func TestQuery_WithHistorical(t *testing.T) {
+	defer testutils.ActivateMocks()()
+
+	url := constants.ThingEndpoint + "?historical=1&id=9&page=2&stats=1"
+	testutils.SetupMockResponder(t, url, "testdata/valid_thing_historical_response.xml")
+
+	client := gogeek.NewClient()
+	thing, err := Query(client, []int{9}, WithHistorical(), WithPage(2))
+	require.NoError(t, err, "Query should not return an error")
+
+	first := Statistics{
+		Date:         "2023-01-01",
+		UsersRated:   IntValue{Value: 3800},
+		Average:      FloatValue{Value: 7.25},
+		BayesAverage: FloatValue{Value: 6.55},
+		Ranks:        []Rank{{Type: "subtype", ID: 1, Name: "boardgame", Friendly: "Board Game Rank", Value: "1100", BayesAverage: "6.55"}},
+		Owned:        IntValue{Value: 8500},
+	}
+	second := Statistics{
+		Date:         "2023-01-02",
+		UsersRated:   IntValue{Value: 3805},
+		Average:      FloatValue{Value: 7.26},
+		BayesAverage: FloatValue{Value: 6.56},
+		Ranks:        []Rank{{Type: "subtype", ID: 1, Name: "boardgame", Friendly: "Board Game Rank", Value: "1098", BayesAverage: "6.56"}},
+		Owned:        IntValue{Value: 8510},
+	}
+
+	expected := &Items{
+		Items: []Item{
+			{
+				Type:       "boardgame",
+				ID:         9,
+				Name:       []Name{{Type: "primary", SortIndex: 1, Value: "Example Game"}},
+				Statistics: &second,
+				History:    &History{Page: 2, Ratings: []Statistics{first, second}},
+			},
+		},
+	}
+
+	if diff := cmp.Diff(expected, thing); diff != "" {
+		t.Errorf("Thing mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -1,6 +1,7 @@
 package thread
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/kkjdaniel/gogeek/v2"
@@ -31,11 +32,17 @@ import (
 //	}
 //	fmt.Printf("Thread subject: %s (contains %d articles)\n", thread.Subject, len(thread.Articles))
 func Query(client *gogeek.Client, threadID int) (*ThreadDetail, error) {
-	url := fmt.Sprintf(constants.ThreadEndpoint+"?id=%d", threadID)
+	return QueryContext(context.Background(), client, threadID)
+}
+
+// QueryContext is like Query but carries a context that can cancel the request
+// while it waits on the rate limiter, is in flight, or is backing off between retries.
+func QueryContext(ctx context.Context, client *gogeek.Client, threadID int) (*ThreadDetail, error) {
+	url := fmt.Sprintf("%s?id=%d", client.Endpoint(constants.ThreadPath), threadID)
 
 	var threadDetail ThreadDetail
 
-	if err := request.FetchAndUnmarshal(client, url, &threadDetail); err != nil {
+	if err := request.FetchAndUnmarshalContext(ctx, client, url, &threadDetail); err != nil {
 		return nil, err
 	}
 
@@ -1,6 +1,7 @@
 package user
 
 import (
+	"context"
 	"fmt"
 	"net/url" // Add this import for URL encoding
 
@@ -33,14 +34,20 @@ import (
 //	}
 //	fmt.Printf("User: %s (member since %s)\n", userProfile.Name, userProfile.YearRegistered)
 func Query(client *gogeek.Client, username string) (*User, error) {
+	return QueryContext(context.Background(), client, username)
+}
+
+// QueryContext is like Query but carries a context that can cancel the request
+// while it waits on the rate limiter, is in flight, or is backing off between retries.
+func QueryContext(ctx context.Context, client *gogeek.Client, username string) (*User, error) {
 	escapedUsername := url.QueryEscape(username)
 
 	requestURL := fmt.Sprintf("%s?name=%s&buddies=1&guilds=1&top=1",
-		constants.UserEndpoint, escapedUsername)
+		client.Endpoint(constants.UserPath), escapedUsername)
 
 	var user User
 
-	if err := request.FetchAndUnmarshal(client, requestURL, &user); err != nil {
+	if err := request.FetchAndUnmarshalContext(ctx, client, requestURL, &user); err != nil {
 		return nil, err
 	}
 